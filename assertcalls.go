@@ -0,0 +1,181 @@
+package smockerclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const closestCallsLimit = 3
+
+// AssertionT The parts of testing.T used to report a failed assertion.
+type AssertionT interface {
+	Helper()
+	Errorf(format string, args ...any)
+}
+
+// CallAssertion Asserts on the requests received by the Smocker mock server in a session. Create it with
+// Instance.AssertCalls, describe the calls expected and finish with Times.
+type CallAssertion struct {
+	t         AssertionT
+	instance  Instance
+	sessionID string
+	matcher   CallMatcher
+	err       error
+}
+
+// AssertCalls Starts an assertion on the requests received by the Smocker mock server in the latest session, e.g.
+//
+//	instance.AssertCalls(t).To(http.MethodPost, "/orders").WithHeader("Idempotency-Key").Times(2)
+func (i Instance) AssertCalls(t AssertionT) CallAssertion {
+	return CallAssertion{t: t, instance: i}
+}
+
+// InSession Asserts on the requests received in the session with the given id instead of the latest session.
+func (a CallAssertion) InSession(sessionID string) CallAssertion {
+	a.sessionID = sessionID
+	return a
+}
+
+// To Only counts requests with the method and path.
+func (a CallAssertion) To(method, path string) CallAssertion {
+	a.matcher.Method = method
+	a.matcher.Path = path
+	return a
+}
+
+// WithHeader Only counts requests with the header, having at least the values given. With no values the header only
+// has to be present.
+func (a CallAssertion) WithHeader(key string, values ...string) CallAssertion {
+	headers := make(map[string][]string, len(a.matcher.Headers)+1)
+	for existingKey, existingValues := range a.matcher.Headers {
+		headers[existingKey] = existingValues
+	}
+	headers[key] = append(headers[key], values...)
+
+	a.matcher.Headers = headers
+	return a
+}
+
+// WithJsonBody Only counts requests with a json body equal to the expected body, ignoring formatting and the order of
+// object keys. The expected body can be json as a string or []byte, or a value to convert to json.
+func (a CallAssertion) WithJsonBody(expected any) CallAssertion {
+	var expectedJson []byte
+	switch body := expected.(type) {
+	case string:
+		expectedJson = []byte(body)
+	case []byte:
+		expectedJson = body
+	default:
+		var err error
+		expectedJson, err = json.Marshal(body)
+		if err != nil {
+			a.err = fmt.Errorf("unable to convert the expected body to json. %w", err)
+			return a
+		}
+	}
+
+	a.matcher.Body = func(body []byte) bool {
+		return jsonEqual(string(expectedJson), string(body))
+	}
+	a.matcher.bodyDescription = excerpt(string(expectedJson))
+	return a
+}
+
+// Times Checks the number of requests matching the assertion is exactly count, reporting an error on the test if it is
+// not. The error lists the closest requests that were received. Returns whether the assertion passed.
+func (a CallAssertion) Times(count int) bool {
+	a.t.Helper()
+
+	if a.err != nil {
+		a.t.Errorf("smockerclient unable to assert calls to %s. %s", a.matcher, a.err)
+		return false
+	}
+
+	history, err := a.instance.GetHistory(a.sessionID)
+	if err != nil {
+		a.t.Errorf("smockerclient unable to assert calls to %s. %s", a.matcher, err)
+		return false
+	}
+
+	var matched []HistoryRequest
+	for _, entry := range history {
+		if a.matcher.Matches(entry.Request) {
+			matched = append(matched, entry.Request)
+		}
+	}
+
+	if len(matched) == count {
+		return true
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "expected %d calls to %s in %s but got %d", count, a.matcher, a.sessionDescription(), len(matched))
+	if len(matched) > count {
+		sb.WriteString("\nmatching calls:")
+		for _, request := range matched {
+			writeObservedCall(&sb, request, nil)
+		}
+	} else {
+		sb.WriteString(a.closestCallsReport(history))
+	}
+
+	a.t.Errorf("%s", sb.String())
+	return false
+}
+
+func (a CallAssertion) sessionDescription() string {
+	if a.sessionID == "" {
+		return "the latest session"
+	}
+
+	return "session " + a.sessionID
+}
+
+// closestCallsReport Lists the requests that did not match, those with the fewest mismatched fields first.
+func (a CallAssertion) closestCallsReport(history []HistoryEntry) string {
+	type observedCall struct {
+		request    HistoryRequest
+		mismatches []string
+	}
+
+	var calls []observedCall
+	for _, entry := range history {
+		mismatches := a.matcher.mismatches(entry.Request)
+		if len(mismatches) > 0 {
+			calls = append(calls, observedCall{request: entry.Request, mismatches: mismatches})
+		}
+	}
+
+	if len(calls) == 0 {
+		return "\nno other calls were received"
+	}
+
+	sort.SliceStable(calls, func(x, y int) bool {
+		return len(calls[x].mismatches) < len(calls[y].mismatches)
+	})
+
+	var sb strings.Builder
+	sb.WriteString("\nclosest calls received:")
+	for _, call := range calls[:min(len(calls), closestCallsLimit)] {
+		writeObservedCall(&sb, call.request, call.mismatches)
+	}
+
+	return sb.String()
+}
+
+func writeObservedCall(sb *strings.Builder, request HistoryRequest, mismatches []string) {
+	fmt.Fprintf(sb, "\n  - %s %s", request.Method, pathWithQuery(request.Path, request.QueryParams))
+	if len(mismatches) > 0 {
+		fmt.Fprintf(sb, " (%s)", strings.Join(mismatches, "; "))
+	}
+
+	if len(request.Headers) > 0 {
+		fmt.Fprintf(sb, "\n      headers: %s", formatHeaders(request.Headers))
+	}
+
+	if body := excerpt(string(request.BodyBytes())); body != "" {
+		fmt.Fprintf(sb, "\n      body: %s", body)
+	}
+}
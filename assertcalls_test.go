@@ -0,0 +1,104 @@
+package smockerclient_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/churmd/smockerclient"
+)
+
+func TestAssertCalls_WhenCallsMatch_Passes(t *testing.T) {
+	server := newHistoryServer(t, getHistoryBody())
+	defer server.Close()
+
+	fakeT := &fakeTestingT{}
+	smockerInstance := smockerclient.Instance{Url: server.URL}
+	passed := smockerInstance.AssertCalls(fakeT).
+		To(http.MethodPost, "/orders").
+		WithHeader("Content-Type", "application/json").
+		WithJsonBody(map[string]int{"id": 1234}).
+		Times(1)
+
+	assert.True(t, passed)
+	assert.Empty(t, fakeT.failures)
+}
+
+func TestAssertCalls_WhenTooFewCallsMatch_ReportsTheClosestCalls(t *testing.T) {
+	server := newHistoryServer(t, getHistoryBody())
+	defer server.Close()
+
+	fakeT := &fakeTestingT{}
+	smockerInstance := smockerclient.Instance{Url: server.URL}
+	passed := smockerInstance.AssertCalls(fakeT).
+		To(http.MethodPost, "/orders").
+		WithHeader("Idempotency-Key").
+		WithJsonBody(`{"id": 1234}`).
+		Times(1)
+
+	expected := `expected 1 calls to POST /orders with headers Idempotency-Key with body {"id": 1234} in the latest session but got 0
+closest calls received:
+  - POST /orders?dry_run=true (missing header Idempotency-Key)
+      headers: Content-Type: application/json
+      body: {"id": 1234}
+  - GET /unknown (method was GET; path was /unknown; missing header Idempotency-Key; body did not match)`
+	assert.False(t, passed)
+	assert.Equal(t, []string{expected}, fakeT.failures)
+}
+
+func TestAssertCalls_WhenTooManyCallsMatch_ReportsTheMatchingCalls(t *testing.T) {
+	server := newHistoryServer(t, getHistoryBody())
+	defer server.Close()
+
+	fakeT := &fakeTestingT{}
+	smockerInstance := smockerclient.Instance{Url: server.URL}
+	passed := smockerInstance.AssertCalls(fakeT).InSession("Z9gF5kwSR").To(http.MethodPost, "/orders").Times(0)
+
+	expected := `expected 0 calls to POST /orders in session Z9gF5kwSR but got 1
+matching calls:
+  - POST /orders?dry_run=true
+      headers: Content-Type: application/json
+      body: {"id": 1234}`
+	assert.False(t, passed)
+	assert.Equal(t, []string{expected}, fakeT.failures)
+}
+
+func TestAssertCalls_WhenNoCallsWereReceived_ReportsIt(t *testing.T) {
+	server := newHistoryServer(t, "[]")
+	defer server.Close()
+
+	fakeT := &fakeTestingT{}
+	smockerInstance := smockerclient.Instance{Url: server.URL}
+	passed := smockerInstance.AssertCalls(fakeT).To(http.MethodGet, "/health").Times(1)
+
+	assert.False(t, passed)
+	assert.Equal(t, []string{"expected 1 calls to GET /health in the latest session but got 0\nno other calls were received"}, fakeT.failures)
+}
+
+func TestAssertCalls_WhenServerDoesNotReturn200_ReportsError(t *testing.T) {
+	server, _ := newBadResponseServer(t)
+	defer server.Close()
+
+	fakeT := &fakeTestingT{}
+	smockerInstance := smockerclient.Instance{Url: server.URL}
+	passed := smockerInstance.AssertCalls(fakeT).To(http.MethodGet, "/health").Times(1)
+
+	assert.False(t, passed)
+	assert.Equal(t, []string{"smockerclient unable to assert calls to GET /health. smockerclient unable to get the history of session . received status:400 and message:400 Bad Request"}, fakeT.failures)
+}
+
+func newHistoryServer(t *testing.T, body string) *httptest.Server {
+	return httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodGet, r.Method)
+				assert.Equal(t, "/history", r.URL.Path)
+
+				_, err := w.Write([]byte(body))
+				assert.NoError(t, err, "httptest server write failed")
+			},
+		),
+	)
+}
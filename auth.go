@@ -0,0 +1,44 @@
+package smockerclient
+
+import (
+	"net/http"
+)
+
+// AdminAuth Adds credentials to the requests sent to the Smocker admin server, e.g. when it is behind an ingress that
+// requires them. BasicAuth, BearerAuth and HeaderAuth cover the common cases, and it can be implemented for others.
+type AdminAuth interface {
+	Authenticate(req *http.Request)
+}
+
+type basicAuth struct {
+	username string
+	password string
+}
+
+// BasicAuth Authenticates with the username and password in a basic Authorization header.
+func BasicAuth(username, password string) AdminAuth {
+	return basicAuth{username: username, password: password}
+}
+
+func (a basicAuth) Authenticate(req *http.Request) {
+	req.SetBasicAuth(a.username, a.password)
+}
+
+type headerAuth struct {
+	name  string
+	value string
+}
+
+// BearerAuth Authenticates with the token in a bearer Authorization header.
+func BearerAuth(token string) AdminAuth {
+	return headerAuth{name: "Authorization", value: "Bearer " + token}
+}
+
+// HeaderAuth Authenticates with a custom header, e.g. an api key header.
+func HeaderAuth(name, value string) AdminAuth {
+	return headerAuth{name: name, value: value}
+}
+
+func (a headerAuth) Authenticate(req *http.Request) {
+	req.Header.Set(a.name, a.value)
+}
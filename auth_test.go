@@ -0,0 +1,91 @@
+package smockerclient_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/churmd/smockerclient"
+)
+
+func TestAuth_AddsCredentialsToAdminRequests(t *testing.T) {
+	tests := map[string]struct {
+		auth           smockerclient.AdminAuth
+		header         string
+		expectedHeader string
+	}{
+		"basic auth": {
+			auth:           smockerclient.BasicAuth("admin", "secret"),
+			header:         "Authorization",
+			expectedHeader: "Basic YWRtaW46c2VjcmV0",
+		},
+		"bearer auth": {
+			auth:           smockerclient.BearerAuth("my-token"),
+			header:         "Authorization",
+			expectedHeader: "Bearer my-token",
+		},
+		"header auth": {
+			auth:           smockerclient.HeaderAuth("X-Api-Key", "my-key"),
+			header:         "X-Api-Key",
+			expectedHeader: "my-key",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			serverCallCount := 0
+
+			server := httptest.NewServer(
+				http.HandlerFunc(
+					func(w http.ResponseWriter, r *http.Request) {
+						serverCallCount++
+						assert.Equal(t, test.expectedHeader, r.Header.Get(test.header))
+					},
+				),
+			)
+			defer server.Close()
+
+			smockerInstance := smockerclient.Instance{Url: server.URL, Auth: test.auth}
+			err := smockerInstance.ResetAllSessionsAndMocks()
+
+			assert.NoError(t, err)
+			assert.Equal(t, 1, serverCallCount)
+		})
+	}
+}
+
+func TestAuth_AddsCredentialsToTheAdminReadinessProbe(t *testing.T) {
+	var adminAuthHeaders, mockAuthHeaders []string
+
+	adminServer := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				adminAuthHeaders = append(adminAuthHeaders, r.Header.Get("Authorization"))
+			},
+		),
+	)
+	defer adminServer.Close()
+
+	mockServer := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				mockAuthHeaders = append(mockAuthHeaders, r.Header.Get("Authorization"))
+			},
+		),
+	)
+	defer mockServer.Close()
+
+	smockerInstance := smockerclient.Instance{
+		Url:           adminServer.URL,
+		MockServerUrl: mockServer.URL,
+		Auth:          smockerclient.BearerAuth("my-token"),
+	}
+	err := smockerInstance.WaitUntilReady(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Bearer my-token"}, adminAuthHeaders)
+	assert.Equal(t, []string{""}, mockAuthHeaders)
+}
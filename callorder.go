@@ -0,0 +1,166 @@
+package smockerclient
+
+import (
+	"context"
+	"fmt"
+)
+
+// CallExpectation Describes a call expected to be made, see CallMatcher.
+type CallExpectation = CallMatcher
+
+// CallOrderError The calls in the history of a session were not made in the expected order.
+type CallOrderError struct {
+	// Expectation The index of the first expectation that was not met in order.
+	Expectation int
+	// Call The first call made out of order, nil when no call matched the expectation.
+	Call *HistoryEntry
+	// Position The index of Call in the history of the session.
+	Position int
+	message  string
+}
+
+func (e *CallOrderError) Error() string {
+	return e.message
+}
+
+// VerifyCallOrder Checks the history of the latest session contains calls matching the expectations in the order given.
+// Other calls can be made before, between and after them. The error is a *CallOrderError pointing to the first call
+// made out of order.
+func (i Instance) VerifyCallOrder(expectations ...CallExpectation) error {
+	return i.VerifyCallOrderContext(context.Background(), expectations...)
+}
+
+// VerifyCallOrderContext Is VerifyCallOrder with a context to cancel the request and set its deadline.
+func (i Instance) VerifyCallOrderContext(ctx context.Context, expectations ...CallExpectation) error {
+	history, err := i.GetHistoryContext(ctx, "")
+	if err != nil {
+		return fmt.Errorf("smockerclient unable to verify the call order. %w", err)
+	}
+
+	return checkInterleavedOrder(history, expectations)
+}
+
+// VerifyStrictCallOrder Checks the history of the latest session contains calls matching the expectations in the order
+// given, one straight after another with no other calls between them. The error is a *CallOrderError pointing to the
+// first call made out of order.
+func (i Instance) VerifyStrictCallOrder(expectations ...CallExpectation) error {
+	return i.VerifyStrictCallOrderContext(context.Background(), expectations...)
+}
+
+// VerifyStrictCallOrderContext Is VerifyStrictCallOrder with a context to cancel the request and set its deadline.
+func (i Instance) VerifyStrictCallOrderContext(ctx context.Context, expectations ...CallExpectation) error {
+	history, err := i.GetHistoryContext(ctx, "")
+	if err != nil {
+		return fmt.Errorf("smockerclient unable to verify the call order. %w", err)
+	}
+
+	return checkStrictOrder(history, expectations)
+}
+
+func checkInterleavedOrder(history []HistoryEntry, expectations []CallExpectation) error {
+	position := 0
+	for index, expectation := range expectations {
+		match := findCall(history, position, expectation)
+		if match == -1 {
+			return newCallOrderError(history, expectations, index, findCall(history, 0, expectation), false)
+		}
+
+		position = match + 1
+	}
+
+	return nil
+}
+
+// checkStrictOrder Tries each call matching the first expectation as the start of the sequence. When none of them
+// are followed by the rest of the expectations, the error is for the attempt that matched the most expectations.
+func checkStrictOrder(history []HistoryEntry, expectations []CallExpectation) error {
+	if len(expectations) == 0 {
+		return nil
+	}
+
+	var closest *CallOrderError
+	closestMatched := -1
+
+	for start := findCall(history, 0, expectations[0]); start != -1; start = findCall(history, start+1, expectations[0]) {
+		matched, orderErr := checkStrictOrderFrom(history, expectations, start)
+		if orderErr == nil {
+			return nil
+		}
+
+		if matched > closestMatched {
+			closest = orderErr
+			closestMatched = matched
+		}
+	}
+
+	if closest == nil {
+		return newCallOrderError(history, expectations, 0, -1, true)
+	}
+
+	return closest
+}
+
+// checkStrictOrderFrom Checks the calls from start match the expectations one after another, returning how many
+// expectations were matched.
+func checkStrictOrderFrom(history []HistoryEntry, expectations []CallExpectation, start int) (int, *CallOrderError) {
+	for index, expectation := range expectations[1:] {
+		position := start + index + 1
+		if position >= len(history) {
+			return index + 1, newCallOrderError(history, expectations, index+1, -1, true)
+		}
+
+		if !expectation.Matches(history[position].Request) {
+			return index + 1, newCallOrderError(history, expectations, index+1, position, true)
+		}
+	}
+
+	return len(expectations), nil
+}
+
+// findCall Gets the index of the first call in the history, from the start index, matching the expectation or -1 if
+// there is none.
+func findCall(history []HistoryEntry, start int, expectation CallExpectation) int {
+	for position := start; position < len(history); position++ {
+		if expectation.Matches(history[position].Request) {
+			return position
+		}
+	}
+
+	return -1
+}
+
+// newCallOrderError Creates the error for the expectation at index, with the position in the history of the call made
+// out of order, or -1 when no call matched the expectation.
+func newCallOrderError(history []HistoryEntry, expectations []CallExpectation, index, position int, strict bool) *CallOrderError {
+	orderErr := &CallOrderError{Expectation: index, Position: position}
+
+	expected := fmt.Sprintf("expectation %d, %s", index+1, expectations[index])
+	previous := ""
+	if index > 0 {
+		previous = fmt.Sprintf("expectation %d, %s", index, expectations[index-1])
+	}
+
+	var reason string
+	switch {
+	case position == -1 && previous == "":
+		reason = fmt.Sprintf("no call matched %s", expected)
+	case position == -1:
+		reason = fmt.Sprintf("no call matched %s after %s", expected, previous)
+	case strict:
+		reason = fmt.Sprintf("%s was made where %s was expected after %s", describeCall(history, position), expected, previous)
+	default:
+		reason = fmt.Sprintf("%s matched %s but was made before %s", describeCall(history, position), expected, previous)
+	}
+
+	if position != -1 {
+		orderErr.Call = &history[position]
+	}
+
+	orderErr.message = "smockerclient calls were not made in the expected order. " + reason
+	return orderErr
+}
+
+func describeCall(history []HistoryEntry, position int) string {
+	request := history[position].Request
+	return fmt.Sprintf("call %d in the history, %s %s,", position+1, request.Method, pathWithQuery(request.Path, request.QueryParams))
+}
@@ -0,0 +1,131 @@
+package smockerclient_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/churmd/smockerclient"
+)
+
+var (
+	authCall     = smockerclient.CallExpectation{Method: http.MethodPost, Path: "/auth"}
+	paymentsCall = smockerclient.CallExpectation{Method: http.MethodPost, Path: "/payments"}
+	ledgerCall   = smockerclient.CallExpectation{Method: http.MethodPut, Path: "/ledger"}
+	refundCall   = smockerclient.CallExpectation{Method: http.MethodPost, Path: "/refunds"}
+)
+
+func TestVerifyCallOrder_WhenCallsAreInOrder_ReturnsNil(t *testing.T) {
+	server := newHistoryServer(t, getOrderedHistoryBody())
+	defer server.Close()
+
+	smockerInstance := smockerclient.Instance{Url: server.URL}
+	err := smockerInstance.VerifyCallOrder(authCall, paymentsCall, ledgerCall)
+
+	assert.NoError(t, err)
+}
+
+func TestVerifyCallOrder_WhenCallIsMadeBeforeThePreviousExpectation_ReturnsCallOrderError(t *testing.T) {
+	server := newHistoryServer(t, getOrderedHistoryBody())
+	defer server.Close()
+
+	smockerInstance := smockerclient.Instance{Url: server.URL}
+	err := smockerInstance.VerifyCallOrder(paymentsCall, authCall)
+
+	var orderErr *smockerclient.CallOrderError
+	assert.ErrorAs(t, err, &orderErr)
+	assert.Equal(t, 1, orderErr.Expectation)
+	assert.Equal(t, 0, orderErr.Position)
+	assert.Equal(t, "/auth", orderErr.Call.Request.Path)
+	assert.EqualError(t, err, "smockerclient calls were not made in the expected order. call 1 in the history, POST /auth, matched expectation 2, POST /auth but was made before expectation 1, POST /payments")
+}
+
+func TestVerifyCallOrder_WhenCallIsMissing_ReturnsCallOrderError(t *testing.T) {
+	server := newHistoryServer(t, getOrderedHistoryBody())
+	defer server.Close()
+
+	smockerInstance := smockerclient.Instance{Url: server.URL}
+	err := smockerInstance.VerifyCallOrder(ledgerCall, refundCall)
+
+	var orderErr *smockerclient.CallOrderError
+	assert.ErrorAs(t, err, &orderErr)
+	assert.Nil(t, orderErr.Call)
+	assert.Equal(t, -1, orderErr.Position)
+	assert.EqualError(t, err, "smockerclient calls were not made in the expected order. no call matched expectation 2, POST /refunds after expectation 1, PUT /ledger")
+}
+
+func TestVerifyStrictCallOrder_WhenCallsFollowEachOther_ReturnsNil(t *testing.T) {
+	server := newHistoryServer(t, getOrderedHistoryBody())
+	defer server.Close()
+
+	smockerInstance := smockerclient.Instance{Url: server.URL}
+	err := smockerInstance.VerifyStrictCallOrder(paymentsCall, ledgerCall)
+
+	assert.NoError(t, err)
+}
+
+func TestVerifyStrictCallOrder_WhenFirstCallIsRepeated_TriesEachStart(t *testing.T) {
+	history := `[
+    {"request": {"method": "POST", "path": "/auth", "date": "2023-04-26T14:42:50Z"}, "response": {"status": 500}},
+    {"request": {"method": "GET", "path": "/health", "date": "2023-04-26T14:42:51Z"}, "response": {"status": 200}},
+    {"request": {"method": "POST", "path": "/auth", "date": "2023-04-26T14:42:52Z"}, "response": {"status": 200}},
+    {"request": {"method": "POST", "path": "/payments", "date": "2023-04-26T14:42:53Z"}, "response": {"status": 201}}
+]`
+	server := newHistoryServer(t, history)
+	defer server.Close()
+
+	smockerInstance := smockerclient.Instance{Url: server.URL}
+
+	err := smockerInstance.VerifyStrictCallOrder(authCall, paymentsCall)
+	assert.NoError(t, err)
+
+	err = smockerInstance.VerifyStrictCallOrder(authCall, paymentsCall, ledgerCall)
+	var orderErr *smockerclient.CallOrderError
+	assert.ErrorAs(t, err, &orderErr)
+	assert.Equal(t, 2, orderErr.Expectation)
+	assert.EqualError(t, err, "smockerclient calls were not made in the expected order. no call matched expectation 3, PUT /ledger after expectation 2, POST /payments")
+}
+
+func TestVerifyStrictCallOrder_WhenAnotherCallIsMadeBetween_ReturnsCallOrderError(t *testing.T) {
+	server := newHistoryServer(t, getOrderedHistoryBody())
+	defer server.Close()
+
+	smockerInstance := smockerclient.Instance{Url: server.URL}
+	err := smockerInstance.VerifyStrictCallOrder(authCall, paymentsCall, ledgerCall)
+
+	var orderErr *smockerclient.CallOrderError
+	assert.ErrorAs(t, err, &orderErr)
+	assert.Equal(t, 1, orderErr.Expectation)
+	assert.Equal(t, 1, orderErr.Position)
+	assert.EqualError(t, err, "smockerclient calls were not made in the expected order. call 2 in the history, GET /users/1, was made where expectation 2, POST /payments was expected after expectation 1, POST /auth")
+}
+
+func TestVerifyStrictCallOrder_WhenHistoryEndsEarly_ReturnsCallOrderError(t *testing.T) {
+	server := newHistoryServer(t, getOrderedHistoryBody())
+	defer server.Close()
+
+	smockerInstance := smockerclient.Instance{Url: server.URL}
+	err := smockerInstance.VerifyStrictCallOrder(ledgerCall, refundCall)
+
+	assert.EqualError(t, err, "smockerclient calls were not made in the expected order. no call matched expectation 2, POST /refunds after expectation 1, PUT /ledger")
+}
+
+func TestVerifyCallOrder_WhenServerDoesNotReturn200_ReturnsError(t *testing.T) {
+	server, _ := newBadResponseServer(t)
+	defer server.Close()
+
+	smockerInstance := smockerclient.Instance{Url: server.URL}
+	err := smockerInstance.VerifyCallOrder(authCall)
+
+	assert.EqualError(t, err, "smockerclient unable to verify the call order. smockerclient unable to get the history of session . received status:400 and message:400 Bad Request")
+}
+
+func getOrderedHistoryBody() string {
+	return `[
+    {"request": {"method": "POST", "path": "/auth", "date": "2023-04-26T14:42:50Z"}, "response": {"status": 200}},
+    {"request": {"method": "GET", "path": "/users/1", "date": "2023-04-26T14:42:51Z"}, "response": {"status": 200}},
+    {"request": {"method": "POST", "path": "/payments", "date": "2023-04-26T14:42:52Z"}, "response": {"status": 201}},
+    {"request": {"method": "PUT", "path": "/ledger", "date": "2023-04-26T14:42:53Z"}, "response": {"status": 200}}
+]`
+}
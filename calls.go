@@ -0,0 +1,110 @@
+package smockerclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const callPollInterval = 100 * time.Millisecond
+
+// CallMatcher Describes a request received by the Smocker mock server. Empty fields match any request.
+type CallMatcher struct {
+	Method string
+	Path   string
+	// Headers The headers the request must have, with at least the values given. A header with no values only has to
+	// be present.
+	Headers map[string][]string
+	// Body Reports whether the request body matches.
+	Body func(body []byte) bool
+
+	// bodyDescription Describes the body the Body func matches, when it is known.
+	bodyDescription string
+}
+
+// Matches Reports whether the request matches all the fields of the matcher.
+func (m CallMatcher) Matches(request HistoryRequest) bool {
+	return len(m.mismatches(request)) == 0
+}
+
+// mismatches Describes each field of the matcher the request does not match.
+func (m CallMatcher) mismatches(request HistoryRequest) []string {
+	var mismatches []string
+
+	if m.Method != "" && !strings.EqualFold(m.Method, request.Method) {
+		mismatches = append(mismatches, "method was "+request.Method)
+	}
+
+	if m.Path != "" && m.Path != request.Path {
+		mismatches = append(mismatches, "path was "+request.Path)
+	}
+
+	headers := http.Header(request.Headers)
+	for _, key := range sortedKeys(m.Headers) {
+		actual := headers.Values(key)
+		if len(actual) == 0 {
+			mismatches = append(mismatches, "missing header "+key)
+		} else if !containsAll(actual, m.Headers[key]) {
+			mismatches = append(mismatches, fmt.Sprintf("header %s was %s", key, strings.Join(actual, ", ")))
+		}
+	}
+
+	if m.Body != nil && !m.Body(request.BodyBytes()) {
+		mismatches = append(mismatches, "body did not match")
+	}
+
+	return mismatches
+}
+
+func (m CallMatcher) String() string {
+	method := m.Method
+	if method == "" {
+		method = "any method"
+	}
+
+	path := m.Path
+	if path == "" {
+		path = "any path"
+	}
+
+	description := method + " " + path
+	if len(m.Headers) > 0 {
+		description += " with headers " + strings.Join(sortedKeys(m.Headers), ", ")
+	}
+
+	if m.bodyDescription != "" {
+		description += " with body " + m.bodyDescription
+	} else if m.Body != nil {
+		description += " with a matching body"
+	}
+
+	return description
+}
+
+// WaitForCall Polls the history of the latest session until the Smocker mock server receives a request matching the
+// matcher or the context is done, and returns the first matching request. Useful when the code under test makes its
+// calls in the background, e.g. from a queue consumer, so verifying straight away would race with it.
+func (i Instance) WaitForCall(ctx context.Context, matcher CallMatcher) (HistoryEntry, error) {
+	for {
+		history, err := i.GetHistoryContext(ctx, "")
+		if err != nil {
+			return HistoryEntry{}, fmt.Errorf("smockerclient unable to wait for a call to %s. %w", matcher, err)
+		}
+
+		for _, entry := range history {
+			if matcher.Matches(entry.Request) {
+				return entry, nil
+			}
+		}
+
+		timer := time.NewTimer(callPollInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return HistoryEntry{}, fmt.Errorf("smockerclient no call to %s was received. %w", matcher, ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package smockerclient_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/churmd/smockerclient"
+)
+
+func TestWaitForCall_WhenCallArrivesLater_ReturnsTheMatchingEntry(t *testing.T) {
+	server, serverCallCount := newDelayedHistoryServer(t, 2)
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	matcher := smockerclient.CallMatcher{
+		Method:  http.MethodPost,
+		Path:    "/orders",
+		Headers: map[string][]string{"content-type": {"application/json"}},
+		Body: func(body []byte) bool {
+			return string(body) == `{"id": 1234}`
+		},
+	}
+
+	smockerInstance := smockerclient.Instance{Url: server.URL}
+	entry, err := smockerInstance.WaitForCall(ctx, matcher)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "bqeh8ks4R", entry.Context.MockID)
+	assert.Equal(t, "/orders", entry.Request.Path)
+	assert.Equal(t, 3, *serverCallCount)
+}
+
+func TestWaitForCall_WhenNoCallMatches_ReturnsErrorWhenContextIsDone(t *testing.T) {
+	server, _ := newDelayedHistoryServer(t, 0)
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 250*time.Millisecond)
+	defer cancel()
+
+	matcher := smockerclient.CallMatcher{Method: http.MethodDelete, Headers: map[string][]string{"X-Request-Id": nil}}
+
+	smockerInstance := smockerclient.Instance{Url: server.URL}
+	_, err := smockerInstance.WaitForCall(ctx, matcher)
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.EqualError(t, err, "smockerclient no call to DELETE any path with headers X-Request-Id was received. context deadline exceeded")
+}
+
+func TestWaitForCall_WhenServerDoesNotReturn200_ReturnsError(t *testing.T) {
+	server, serverCallCount := newBadResponseServer(t)
+	defer server.Close()
+
+	smockerInstance := smockerclient.Instance{Url: server.URL}
+	_, err := smockerInstance.WaitForCall(context.Background(), smockerclient.CallMatcher{Path: "/orders"})
+
+	assert.Equal(t, 1, *serverCallCount)
+	assert.EqualError(t, err, "smockerclient unable to wait for a call to any method /orders. smockerclient unable to get the history of session . received status:400 and message:400 Bad Request")
+}
+
+func TestCallMatcher_Matches(t *testing.T) {
+	request := smockerclient.HistoryRequest{
+		Method:     http.MethodPost,
+		Path:       "/orders",
+		Headers:    map[string][]string{"Content-Type": {"application/json"}, "Accept": {"text/plain", "application/json"}},
+		BodyString: `{"id": 1234}`,
+	}
+
+	tests := map[string]struct {
+		matcher  smockerclient.CallMatcher
+		expected bool
+	}{
+		"empty matcher":              {matcher: smockerclient.CallMatcher{}, expected: true},
+		"method is case insensitive": {matcher: smockerclient.CallMatcher{Method: "post"}, expected: true},
+		"different method":           {matcher: smockerclient.CallMatcher{Method: http.MethodGet}, expected: false},
+		"different path":             {matcher: smockerclient.CallMatcher{Path: "/order"}, expected: false},
+		"header present":             {matcher: smockerclient.CallMatcher{Headers: map[string][]string{"Accept": nil}}, expected: true},
+		"header missing":             {matcher: smockerclient.CallMatcher{Headers: map[string][]string{"Authorization": nil}}, expected: false},
+		"header value present": {
+			matcher:  smockerclient.CallMatcher{Headers: map[string][]string{"Accept": {"application/json"}}},
+			expected: true,
+		},
+		"header value missing": {
+			matcher:  smockerclient.CallMatcher{Headers: map[string][]string{"Accept": {"text/html"}}},
+			expected: false,
+		},
+		"body does not match": {
+			matcher:  smockerclient.CallMatcher{Body: func(body []byte) bool { return len(body) == 0 }},
+			expected: false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.matcher.Matches(request))
+		})
+	}
+}
+
+// newDelayedHistoryServer Responds with an empty history to the first emptyCount requests and the history from
+// getHistoryBody after.
+func newDelayedHistoryServer(t *testing.T, emptyCount int) (*httptest.Server, *int) {
+	serverCallCount := 0
+
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				serverCallCount++
+
+				assert.Equal(t, http.MethodGet, r.Method)
+				assert.Equal(t, "/history", r.URL.Path)
+
+				body := getHistoryBody()
+				if serverCallCount <= emptyCount {
+					body = "[]"
+				}
+
+				_, err := w.Write([]byte(body))
+				assert.NoError(t, err, "httptest server write failed")
+			},
+		),
+	)
+
+	return server, &serverCallCount
+}
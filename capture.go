@@ -0,0 +1,41 @@
+package smockerclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// CapturedBodies Gets the body of each request with the method and path received in the latest session, oldest first,
+// decoded from json into T. Useful to assert on generated fields, e.g. ids or timestamps, that a mock cannot match
+// exactly.
+func CapturedBodies[T any](instance Instance, method, path string) ([]T, error) {
+	return CapturedBodiesContext[T](context.Background(), instance, method, path)
+}
+
+// CapturedBodiesContext Is CapturedBodies with a context to cancel the request and set its deadline.
+func CapturedBodiesContext[T any](ctx context.Context, instance Instance, method, path string) ([]T, error) {
+	history, err := instance.GetHistoryContext(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf("smockerclient unable to capture the bodies sent to %s %s. %w", method, path, err)
+	}
+
+	matcher := CallMatcher{Method: method, Path: path}
+
+	var bodies []T
+	for position, entry := range history {
+		if !matcher.Matches(entry.Request) {
+			continue
+		}
+
+		var body T
+		err = json.Unmarshal(entry.Request.BodyBytes(), &body)
+		if err != nil {
+			return nil, fmt.Errorf("smockerclient unable to decode the body of call %d in the history, %s %s. %w", position+1, method, path, err)
+		}
+
+		bodies = append(bodies, body)
+	}
+
+	return bodies, nil
+}
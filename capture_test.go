@@ -0,0 +1,93 @@
+package smockerclient_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/churmd/smockerclient"
+)
+
+type order struct {
+	ID        string `json:"id"`
+	CreatedAt string `json:"created_at"`
+}
+
+func TestCapturedBodies_DecodesTheBodyOfEachMatchingCall(t *testing.T) {
+	server := newHistoryServer(t, getCapturedHistoryBody())
+	defer server.Close()
+
+	smockerInstance := smockerclient.Instance{Url: server.URL}
+	bodies, err := smockerclient.CapturedBodies[order](smockerInstance, http.MethodPost, "/orders")
+
+	expected := []order{
+		{ID: "0b8f6a52-5b0e-4d4a-9f55-1d2c6f0f6e1a", CreatedAt: "2023-04-26T14:42:50Z"},
+		{ID: "7d1e1c2b-43a1-4f0e-8a4b-2f7a9f3d1c55", CreatedAt: "2023-04-26T14:42:52Z"},
+	}
+	assert.NoError(t, err)
+	assert.Equal(t, expected, bodies)
+}
+
+func TestCapturedBodies_WhenNoCallsMatch_ReturnsNoBodies(t *testing.T) {
+	server := newHistoryServer(t, getCapturedHistoryBody())
+	defer server.Close()
+
+	smockerInstance := smockerclient.Instance{Url: server.URL}
+	bodies, err := smockerclient.CapturedBodies[order](smockerInstance, http.MethodDelete, "/orders")
+
+	assert.NoError(t, err)
+	assert.Empty(t, bodies)
+}
+
+func TestCapturedBodies_WhenBodyIsNotJson_ReturnsError(t *testing.T) {
+	server := newHistoryServer(t, getCapturedHistoryBody())
+	defer server.Close()
+
+	smockerInstance := smockerclient.Instance{Url: server.URL}
+	_, err := smockerclient.CapturedBodies[order](smockerInstance, http.MethodPost, "/notes")
+
+	assert.EqualError(t, err, "smockerclient unable to decode the body of call 3 in the history, POST /notes. invalid character 'h' looking for beginning of value")
+}
+
+func TestCapturedBodies_WhenServerDoesNotReturn200_ReturnsError(t *testing.T) {
+	server, _ := newBadResponseServer(t)
+	defer server.Close()
+
+	smockerInstance := smockerclient.Instance{Url: server.URL}
+	_, err := smockerclient.CapturedBodies[order](smockerInstance, http.MethodPost, "/orders")
+
+	assert.EqualError(t, err, "smockerclient unable to capture the bodies sent to POST /orders. smockerclient unable to get the history of session . received status:400 and message:400 Bad Request")
+}
+
+func getCapturedHistoryBody() string {
+	return `[
+    {
+        "request": {
+            "method": "POST",
+            "path": "/orders",
+            "body_string": "{\"id\": \"0b8f6a52-5b0e-4d4a-9f55-1d2c6f0f6e1a\", \"created_at\": \"2023-04-26T14:42:50Z\"}",
+            "body": {"id": "0b8f6a52-5b0e-4d4a-9f55-1d2c6f0f6e1a", "created_at": "2023-04-26T14:42:50Z"},
+            "date": "2023-04-26T14:42:50Z"
+        },
+        "response": {"status": 201}
+    },
+    {
+        "request": {"method": "GET", "path": "/orders", "date": "2023-04-26T14:42:51Z"},
+        "response": {"status": 200}
+    },
+    {
+        "request": {"method": "POST", "path": "/notes", "body": "hello", "date": "2023-04-26T14:42:51Z"},
+        "response": {"status": 201}
+    },
+    {
+        "request": {
+            "method": "POST",
+            "path": "/orders",
+            "body": {"id": "7d1e1c2b-43a1-4f0e-8a4b-2f7a9f3d1c55", "created_at": "2023-04-26T14:42:52Z"},
+            "date": "2023-04-26T14:42:52Z"
+        },
+        "response": {"status": 201}
+    }
+]`
+}
@@ -0,0 +1,60 @@
+package smockerclient
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+)
+
+const (
+	envAdminUrl      = "SMOCKER_ADMIN_URL"
+	envMockServerUrl = "SMOCKER_MOCK_URL"
+	envTimeout       = "SMOCKER_TIMEOUT"
+)
+
+// DefaultMockServerUrl The default url of the smocker mock server, the server the code under test calls
+var DefaultMockServerUrl = "http://localhost:8080"
+
+// FromEnv Creates an instance from the environment variables:
+//   - SMOCKER_ADMIN_URL The url of the Smocker admin server, defaults to DefaultUrl.
+//   - SMOCKER_MOCK_URL The url of the Smocker mock server, defaults to DefaultMockServerUrl.
+//   - SMOCKER_TIMEOUT The timeout of each request to the admin server, as a duration such as 5s or a number of
+//     seconds. No timeout is set when it is empty.
+func FromEnv() (Instance, error) {
+	instance := Instance{
+		Url:           os.Getenv(envAdminUrl),
+		MockServerUrl: os.Getenv(envMockServerUrl),
+	}
+
+	if instance.MockServerUrl == "" {
+		instance.MockServerUrl = DefaultMockServerUrl
+	}
+
+	err := instance.Validate()
+	if err != nil {
+		return Instance{}, fmt.Errorf("smockerclient unable to read %s. %w", envAdminUrl, err)
+	}
+
+	timeout := os.Getenv(envTimeout)
+	if timeout != "" {
+		duration, err := parseTimeout(timeout)
+		if err != nil {
+			return Instance{}, fmt.Errorf("smockerclient unable to read %s. %w", envTimeout, err)
+		}
+
+		instance.HttpClient = &http.Client{Timeout: duration}
+	}
+
+	return instance, nil
+}
+
+func parseTimeout(timeout string) (time.Duration, error) {
+	seconds, err := strconv.Atoi(timeout)
+	if err == nil {
+		return time.Duration(seconds) * time.Second, nil
+	}
+
+	return time.ParseDuration(timeout)
+}
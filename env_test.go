@@ -0,0 +1,65 @@
+package smockerclient_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/churmd/smockerclient"
+)
+
+func TestFromEnv_ReadsTheSmockerVariables(t *testing.T) {
+	t.Setenv("SMOCKER_ADMIN_URL", "http://smocker:8081")
+	t.Setenv("SMOCKER_MOCK_URL", "http://smocker:8080")
+	t.Setenv("SMOCKER_TIMEOUT", "5s")
+
+	instance, err := smockerclient.FromEnv()
+
+	expected := smockerclient.Instance{
+		Url:           "http://smocker:8081",
+		MockServerUrl: "http://smocker:8080",
+		HttpClient:    &http.Client{Timeout: 5 * time.Second},
+	}
+	assert.NoError(t, err)
+	assert.Equal(t, expected, instance)
+}
+
+func TestFromEnv_WhenVariablesAreNotSet_UsesTheDefaults(t *testing.T) {
+	t.Setenv("SMOCKER_ADMIN_URL", "")
+	t.Setenv("SMOCKER_MOCK_URL", "")
+	t.Setenv("SMOCKER_TIMEOUT", "")
+
+	instance, err := smockerclient.FromEnv()
+
+	expected := smockerclient.Instance{MockServerUrl: "http://localhost:8080"}
+	assert.NoError(t, err)
+	assert.Equal(t, expected, instance)
+}
+
+func TestFromEnv_WhenTimeoutIsANumber_ReadsItAsSeconds(t *testing.T) {
+	t.Setenv("SMOCKER_TIMEOUT", "10")
+
+	instance, err := smockerclient.FromEnv()
+
+	assert.NoError(t, err)
+	assert.Equal(t, 10*time.Second, instance.HttpClient.Timeout)
+}
+
+func TestFromEnv_WhenTimeoutIsInvalid_ReturnsError(t *testing.T) {
+	t.Setenv("SMOCKER_TIMEOUT", "soon")
+
+	_, err := smockerclient.FromEnv()
+
+	assert.EqualError(t, err, `smockerclient unable to read SMOCKER_TIMEOUT. time: invalid duration "soon"`)
+}
+
+func TestFromEnv_WhenAdminUrlIsInvalid_ReturnsErrInvalidUrl(t *testing.T) {
+	t.Setenv("SMOCKER_ADMIN_URL", "smocker:8081")
+
+	_, err := smockerclient.FromEnv()
+
+	assert.ErrorIs(t, err, smockerclient.ErrInvalidUrl)
+	assert.ErrorContains(t, err, "smockerclient unable to read SMOCKER_ADMIN_URL. ")
+}
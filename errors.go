@@ -0,0 +1,65 @@
+package smockerclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrServerUnreachable The request could not be sent to the Smocker server, e.g. the connection was refused.
+	ErrServerUnreachable = errors.New("smocker server unreachable")
+	// ErrInvalidDefinition A mock definition could not be converted to json.
+	ErrInvalidDefinition = errors.New("invalid mock definition")
+	// ErrMockIDUnknown The mocks were added to the Smocker server but their ids could not be found afterwards.
+	ErrMockIDUnknown = errors.New("mock added but its id is unknown")
+	// ErrInvalidUrl The Instance's Url is not an absolute http or https url.
+	ErrInvalidUrl = errors.New("invalid smocker url")
+)
+
+// StatusError The Smocker server responded with a status other than 200 OK.
+type StatusError struct {
+	StatusCode int
+	Body       string
+	// Operation The method and path of the request that failed, e.g. "POST /sessions".
+	Operation string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("received status:%d and message:%s", e.StatusCode, e.Body)
+}
+
+// sentinelError Marks an error as one of the sentinel errors, so it can be matched with errors.Is, without changing its
+// message.
+type sentinelError struct {
+	sentinel error
+	err      error
+}
+
+func (e sentinelError) Error() string {
+	return e.err.Error()
+}
+
+func (e sentinelError) Unwrap() []error {
+	return []error{e.sentinel, e.err}
+}
+
+func markUnreachable(err error) error {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return err
+	}
+
+	return sentinelError{sentinel: ErrServerUnreachable, err: err}
+}
+
+func markInvalidDefinition(err error) error {
+	return sentinelError{sentinel: ErrInvalidDefinition, err: err}
+}
+
+func markInvalidUrl(err error) error {
+	return sentinelError{sentinel: ErrInvalidUrl, err: err}
+}
+
+func markMockIDUnknown(err error) error {
+	return sentinelError{sentinel: ErrMockIDUnknown, err: err}
+}
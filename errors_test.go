@@ -0,0 +1,100 @@
+package smockerclient_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/churmd/smockerclient"
+)
+
+func TestStatusError_WhenServerDoesNotReturn200(t *testing.T) {
+	server, _ := newBadResponseServer(t)
+	defer server.Close()
+
+	smockerInstance := smockerclient.Instance{Url: server.URL}
+	err := smockerInstance.StartSession("my-new-session")
+
+	var statusErr *smockerclient.StatusError
+	assert.ErrorAs(t, err, &statusErr)
+	expected := &smockerclient.StatusError{
+		StatusCode: http.StatusBadRequest,
+		Body:       "400 Bad Request",
+		Operation:  "POST /sessions",
+	}
+	assert.Equal(t, expected, statusErr)
+}
+
+func TestErrServerUnreachable_WhenServerIsNotRunning(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	smockerInstance := smockerclient.Instance{Url: server.URL}
+	err := smockerInstance.ResetAllSessionsAndMocks()
+
+	assert.ErrorIs(t, err, smockerclient.ErrServerUnreachable)
+	assert.ErrorContains(t, err, "smockerclient unable to reset all the sessions and mocks. unable to send request. Post")
+}
+
+func TestErrServerUnreachable_IsNotUsedWhenContextIsDone(t *testing.T) {
+	server := newHangingServer()
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	smockerInstance := smockerclient.Instance{Url: server.URL}
+	err := smockerInstance.ResetAllSessionsAndMocksContext(ctx)
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.NotErrorIs(t, err, smockerclient.ErrServerUnreachable)
+}
+
+func TestErrInvalidDefinition_WhenMockJsonConversionErrors(t *testing.T) {
+	mockError := errors.New("fails mock json conversion")
+
+	smockerInstance := smockerclient.Instance{Url: "http://localhost:0"}
+	err := smockerInstance.AddMock(FakeMock{Error: mockError})
+
+	assert.ErrorIs(t, err, smockerclient.ErrInvalidDefinition)
+	assert.ErrorIs(t, err, mockError)
+}
+
+func TestErrInvalidDefinition_WhenMockJsonIsInvalid(t *testing.T) {
+	smockerInstance := smockerclient.Instance{Url: "http://localhost:0"}
+	_, err := smockerInstance.AddMocks(FakeMock{Json: `{"example":`})
+
+	assert.ErrorIs(t, err, smockerclient.ErrInvalidDefinition)
+}
+
+func TestVerificationError_WhenVerificationFails(t *testing.T) {
+	server := newVerifyServer(t, getVerifyBodyWhenSomeMocksAreNotCalled())
+	defer server.Close()
+
+	smockerInstance := smockerclient.Instance{Url: server.URL}
+	err := smockerInstance.VerifyMocksInCurrentSession()
+
+	var verificationErr *smockerclient.VerificationError
+	assert.ErrorAs(t, err, &verificationErr)
+	assert.Empty(t, verificationErr.SessionID)
+	assert.False(t, verificationErr.Result.Mocks.AllUsed)
+	assert.Len(t, verificationErr.Result.Mocks.Unused, 1)
+}
+
+func TestVerificationError_WhenVerifyingASession_IncludesTheSessionID(t *testing.T) {
+	server := newVerifyServer(t, getVerifyBodyWhenExtraCallsHaveBeenMade())
+	defer server.Close()
+
+	smockerInstance := smockerclient.Instance{Url: server.URL}
+	_, err := smockerInstance.VerifySession("Z9gF5kwSR")
+
+	var verificationErr *smockerclient.VerificationError
+	assert.ErrorAs(t, err, &verificationErr)
+	assert.Equal(t, "Z9gF5kwSR", verificationErr.SessionID)
+	assert.False(t, verificationErr.Result.History.Verified)
+}
@@ -0,0 +1,115 @@
+package smockerclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// HistoryEntry A request received by the Smocker mock server along with the response it was given.
+type HistoryEntry struct {
+	Context  HistoryContext  `json:"context"`
+	Request  HistoryRequest  `json:"request"`
+	Response HistoryResponse `json:"response"`
+}
+
+// HistoryContext Describes which mock, if any, was matched by the request.
+type HistoryContext struct {
+	MockID   string `json:"mock_id,omitempty"`
+	MockType string `json:"mock_type,omitempty"`
+}
+
+type HistoryRequest struct {
+	Method      string              `json:"method"`
+	Path        string              `json:"path"`
+	QueryParams map[string][]string `json:"query_params,omitempty"`
+	Headers     map[string][]string `json:"headers,omitempty"`
+	// Body The request body, Smocker stores json bodies as json and any other body as a json string.
+	Body json.RawMessage `json:"body,omitempty"`
+	// BodyString The request body exactly as it was received.
+	BodyString string    `json:"body_string,omitempty"`
+	Origin     string    `json:"origin,omitempty"`
+	Date       time.Time `json:"date"`
+}
+
+// BodyBytes The request body as it was received.
+func (r HistoryRequest) BodyBytes() []byte {
+	if r.BodyString != "" {
+		return []byte(r.BodyString)
+	}
+
+	var text string
+	err := json.Unmarshal(r.Body, &text)
+	if err == nil {
+		return []byte(text)
+	}
+
+	return r.Body
+}
+
+type HistoryResponse struct {
+	Status  int                 `json:"status"`
+	Headers map[string][]string `json:"headers,omitempty"`
+	// Body The response body, Smocker stores json bodies as json and any other body as a json string.
+	Body json.RawMessage `json:"body,omitempty"`
+	Date time.Time       `json:"date"`
+}
+
+// GetHistory Gets the requests received by the Smocker mock server in the session with the given id, oldest first. An
+// empty session id gets the history of the latest session.
+func (i Instance) GetHistory(sessionID string) ([]HistoryEntry, error) {
+	return i.GetHistoryContext(context.Background(), sessionID)
+}
+
+// GetHistoryContext Is GetHistory with a context to cancel the request and set its deadline.
+func (i Instance) GetHistoryContext(ctx context.Context, sessionID string) ([]HistoryEntry, error) {
+	resp, err := i.sendGetHistoryRequest(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("smockerclient unable to get the history of session %s. %w", sessionID, err)
+	}
+	defer resp.Body.Close()
+
+	err = handleNon200Response(resp)
+	if err != nil {
+		return nil, fmt.Errorf("smockerclient unable to get the history of session %s. %w", sessionID, err)
+	}
+
+	var history []HistoryEntry
+	err = json.NewDecoder(resp.Body).Decode(&history)
+	if err != nil {
+		return nil, fmt.Errorf("smockerclient unable to read json response to get the history of session %s. %w", sessionID, err)
+	}
+
+	return history, nil
+}
+
+func (i Instance) sendGetHistoryRequest(ctx context.Context, sessionID string) (*http.Response, error) {
+	req, err := i.createGetHistoryRequest(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create request. %w", err)
+	}
+
+	resp, err := i.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("unable to send request. %w", err)
+	}
+
+	return resp, nil
+}
+
+func (i Instance) createGetHistoryRequest(ctx context.Context, sessionID string) (*http.Request, error) {
+	url, err := i.endpoint("/history")
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	addSessionQueryParam(req, sessionID)
+	return req, nil
+}
@@ -0,0 +1,157 @@
+package smockerclient_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/churmd/smockerclient"
+)
+
+func TestGetHistory(t *testing.T) {
+	serverCallCount := 0
+	sessionID := "Z9gF5kwSR"
+
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				serverCallCount++
+
+				assert.Equal(t, http.MethodGet, r.Method)
+				assert.Equal(t, "/history", r.URL.Path)
+				assert.Equal(t, sessionID, r.URL.Query().Get("session"))
+
+				_, err := w.Write([]byte(getHistoryBody()))
+				assert.NoError(t, err, "httptest server write failed")
+			},
+		),
+	)
+	defer server.Close()
+
+	smockerInstance := smockerclient.Instance{Url: server.URL}
+	history, err := smockerInstance.GetHistory(sessionID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, serverCallCount)
+
+	expected := []smockerclient.HistoryEntry{
+		{
+			Context: smockerclient.HistoryContext{MockID: "bqeh8ks4R", MockType: "static"},
+			Request: smockerclient.HistoryRequest{
+				Method:      http.MethodPost,
+				Path:        "/orders",
+				QueryParams: map[string][]string{"dry_run": {"true"}},
+				Headers:     map[string][]string{"Content-Type": {"application/json"}},
+				Body:        []byte(`{"id": 1234}`),
+				BodyString:  `{"id": 1234}`,
+				Origin:      "172.17.0.1",
+				Date:        time.Date(2023, 4, 26, 14, 42, 53, 0, time.UTC),
+			},
+			Response: smockerclient.HistoryResponse{
+				Status:  http.StatusCreated,
+				Headers: map[string][]string{"Content-Type": {"application/json"}},
+				Body:    []byte(`{"status": "created"}`),
+				Date:    time.Date(2023, 4, 26, 14, 42, 54, 0, time.UTC),
+			},
+		},
+		{
+			Request: smockerclient.HistoryRequest{
+				Method: http.MethodGet,
+				Path:   "/unknown",
+				Body:   []byte(`""`),
+				Origin: "172.17.0.1",
+				Date:   time.Date(2023, 4, 26, 14, 43, 0, 0, time.UTC),
+			},
+			Response: smockerclient.HistoryResponse{
+				Status: 666,
+				Body:   []byte(`{"message": "No mock found matching the request"}`),
+				Date:   time.Date(2023, 4, 26, 14, 43, 1, 0, time.UTC),
+			},
+		},
+	}
+	assert.Equal(t, expected, history)
+}
+
+func TestGetHistory_WithNoSessionID_DoesNotSendSessionQueryParam(t *testing.T) {
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/history", r.URL.Path)
+				assert.False(t, r.URL.Query().Has("session"))
+
+				_, err := w.Write([]byte("[]"))
+				assert.NoError(t, err, "httptest server write failed")
+			},
+		),
+	)
+	defer server.Close()
+
+	smockerInstance := smockerclient.Instance{Url: server.URL}
+	history, err := smockerInstance.GetHistory("")
+
+	assert.NoError(t, err)
+	assert.Empty(t, history)
+}
+
+func TestGetHistory_WhenServerDoesNotReturn200_ReturnsError(t *testing.T) {
+	server, serverCallCount := newBadResponseServer(t)
+	defer server.Close()
+
+	smockerInstance := smockerclient.Instance{Url: server.URL}
+	history, err := smockerInstance.GetHistory("Z9gF5kwSR")
+
+	assert.Equal(t, 1, *serverCallCount)
+	assert.Nil(t, history)
+	assert.EqualError(t, err, "smockerclient unable to get the history of session Z9gF5kwSR. received status:400 and message:400 Bad Request")
+}
+
+func getHistoryBody() string {
+	return `[
+    {
+        "context": {
+            "mock_id": "bqeh8ks4R",
+            "mock_type": "static"
+        },
+        "request": {
+            "path": "/orders",
+            "method": "POST",
+            "origin": "172.17.0.1",
+            "body_string": "{\"id\": 1234}",
+            "body": {"id": 1234},
+            "query_params": {
+                "dry_run": ["true"]
+            },
+            "headers": {
+                "Content-Type": ["application/json"]
+            },
+            "date": "2023-04-26T14:42:53Z"
+        },
+        "response": {
+            "status": 201,
+            "body": {"status": "created"},
+            "headers": {
+                "Content-Type": ["application/json"]
+            },
+            "date": "2023-04-26T14:42:54Z"
+        }
+    },
+    {
+        "context": {},
+        "request": {
+            "path": "/unknown",
+            "method": "GET",
+            "origin": "172.17.0.1",
+            "body": "",
+            "date": "2023-04-26T14:43:00Z"
+        },
+        "response": {
+            "status": 666,
+            "body": {"message": "No mock found matching the request"},
+            "date": "2023-04-26T14:43:01Z"
+        }
+    }
+]`
+}
@@ -0,0 +1,96 @@
+package smockerclient
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+const logMessage = "smocker admin request"
+
+// logRequest Logs a request sent to the Smocker admin server with its method, url, duration, status and the start of the
+// request and response bodies. The full request body, e.g. the mock definitions json, is logged when the logger has
+// debug level enabled.
+func (i Instance) logRequest(req *http.Request, resp *http.Response, err error, duration time.Duration) {
+	if i.Logger == nil {
+		return
+	}
+
+	ctx := req.Context()
+	debug := i.Logger.Enabled(ctx, slog.LevelDebug)
+
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("url", req.URL.Redacted()),
+		slog.Duration("duration", duration),
+	}
+
+	if body := requestBody(req); body != "" {
+		if !debug {
+			body = excerpt(body)
+		}
+		attrs = append(attrs, slog.String("request_body", body))
+	}
+
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+		i.Logger.LogAttrs(ctx, slog.LevelWarn, logMessage, attrs...)
+		return
+	}
+
+	attrs = append(attrs, slog.Int("status", resp.StatusCode))
+	if body := peekResponseBody(resp); body != "" {
+		attrs = append(attrs, slog.String("response_body", body))
+	}
+
+	level := slog.LevelInfo
+	if resp.StatusCode != http.StatusOK {
+		level = slog.LevelWarn
+	}
+	i.Logger.LogAttrs(ctx, level, logMessage, attrs...)
+}
+
+// requestBody Reads a copy of the request body, leaving the body to be sent unread.
+func requestBody(req *http.Request) string {
+	if req.GetBody == nil {
+		return ""
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return ""
+	}
+	defer body.Close()
+
+	content, err := io.ReadAll(body)
+	if err != nil {
+		return ""
+	}
+
+	return string(content)
+}
+
+// peekResponseBody Reads the start of the response body and puts it back, so the response can still be read in full.
+func peekResponseBody(resp *http.Response) string {
+	start := make([]byte, bodyExcerptLength+1)
+	n, err := io.ReadFull(resp.Body, start)
+	start = start[:n]
+
+	resp.Body = readCloser{
+		Reader: io.MultiReader(bytes.NewReader(start), resp.Body),
+		Closer: resp.Body,
+	}
+
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return ""
+	}
+
+	return excerpt(string(start))
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
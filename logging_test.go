@@ -0,0 +1,138 @@
+package smockerclient_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/churmd/smockerclient"
+	"github.com/churmd/smockerclient/mock"
+)
+
+func TestLogger_LogsEachAdminRequest(t *testing.T) {
+	server, _ := newBadResponseServer(t)
+	defer server.Close()
+
+	logs := &bytes.Buffer{}
+	logger := slog.New(slog.NewJSONHandler(logs, nil))
+
+	smockerInstance := smockerclient.Instance{Url: server.URL, Logger: logger}
+	err := smockerInstance.StartSession("my-new-session")
+	assert.Error(t, err)
+
+	records := decodeLogRecords(t, logs)
+	assert.Len(t, records, 1)
+	assert.Equal(t, "WARN", records[0]["level"])
+	assert.Equal(t, "smocker admin request", records[0]["msg"])
+	assert.Equal(t, "POST", records[0]["method"])
+	assert.Equal(t, server.URL+"/sessions?name=my-new-session", records[0]["url"])
+	assert.Equal(t, float64(http.StatusBadRequest), records[0]["status"])
+	assert.Equal(t, "400 Bad Request", records[0]["response_body"])
+	assert.Contains(t, records[0], "duration")
+}
+
+func TestLogger_TruncatesRequestBodyUnlessDebugIsEnabled(t *testing.T) {
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {},
+		),
+	)
+	defer server.Close()
+
+	longPath := "/" + strings.Repeat("a", 300)
+	definition := mock.NewDefinition(
+		mock.NewRequestBuilder(http.MethodGet, longPath).Build(),
+		mock.NewResponseBuilder(http.StatusOK).Build(),
+	)
+
+	tests := map[string]struct {
+		level    slog.Level
+		fullBody bool
+	}{
+		"info level truncates the body":  {level: slog.LevelInfo, fullBody: false},
+		"debug level logs the full body": {level: slog.LevelDebug, fullBody: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			logs := &bytes.Buffer{}
+			logger := slog.New(slog.NewJSONHandler(logs, &slog.HandlerOptions{Level: test.level}))
+
+			smockerInstance := smockerclient.Instance{Url: server.URL, Logger: logger}
+			err := smockerInstance.AddMock(definition)
+			assert.NoError(t, err)
+
+			records := decodeLogRecords(t, logs)
+			assert.Len(t, records, 1)
+
+			addRecord := records[0]
+			assert.Equal(t, "INFO", addRecord["level"])
+			assert.Equal(t, "POST", addRecord["method"])
+			assert.Equal(t, float64(http.StatusOK), addRecord["status"])
+
+			body := addRecord["request_body"].(string)
+			if test.fullBody {
+				assert.Contains(t, body, longPath)
+				assert.True(t, json.Valid([]byte(body)))
+			} else {
+				assert.NotContains(t, body, longPath)
+				assert.True(t, strings.HasSuffix(body, "..."))
+			}
+		})
+	}
+}
+
+func TestLogger_WhenServerIsNotRunning_LogsTheError(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	logs := &bytes.Buffer{}
+	logger := slog.New(slog.NewJSONHandler(logs, nil))
+
+	smockerInstance := smockerclient.Instance{Url: server.URL, Logger: logger}
+	err := smockerInstance.ResetAllSessionsAndMocks()
+	assert.Error(t, err)
+
+	records := decodeLogRecords(t, logs)
+	assert.Len(t, records, 1)
+	assert.Equal(t, "WARN", records[0]["level"])
+	assert.Contains(t, records[0]["error"], "connection refused")
+	assert.NotContains(t, records[0], "status")
+}
+
+func TestLogger_RedactsThePasswordInTheUrl(t *testing.T) {
+	server, _ := newBadResponseServer(t)
+	defer server.Close()
+
+	logs := &bytes.Buffer{}
+	logger := slog.New(slog.NewJSONHandler(logs, nil))
+
+	url := strings.Replace(server.URL, "http://", "http://admin:secret@", 1)
+	smockerInstance := smockerclient.Instance{Url: url, Logger: logger}
+	err := smockerInstance.ResetAllSessionsAndMocks()
+	assert.Error(t, err)
+	assert.NotContains(t, logs.String(), "secret")
+
+	records := decodeLogRecords(t, logs)
+	assert.Len(t, records, 1)
+	assert.Equal(t, strings.Replace(server.URL, "http://", "http://admin:xxxxx@", 1)+"/reset", records[0]["url"])
+}
+
+func decodeLogRecords(t *testing.T, logs *bytes.Buffer) []map[string]any {
+	var records []map[string]any
+
+	decoder := json.NewDecoder(logs)
+	for decoder.More() {
+		var record map[string]any
+		assert.NoError(t, decoder.Decode(&record))
+		records = append(records, record)
+	}
+
+	return records
+}
@@ -0,0 +1,161 @@
+package mock
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// UnmarshalJSON Decodes a request as it is returned by the Smocker server. Smocker expands the path, method, query params
+// and headers into matchers, e.g. {"matcher": "ShouldEqual", "value": "/example"}. The matchers of the method and path
+// are kept in MethodMatcher and PathMatcher, only the values of the query param and header matchers are kept. The plain
+// format produced by ToMockDefinitionJson is also accepted.
+func (r *Request) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Method      stringMatcher   `json:"method"`
+		Path        stringMatcher   `json:"path"`
+		QueryParams multiMapMatcher `json:"query_params"`
+		Headers     multiMapMatcher `json:"headers"`
+		Body        *bodyMatcher    `json:"body"`
+	}
+
+	err := json.Unmarshal(data, &raw)
+	if err != nil {
+		return err
+	}
+
+	*r = Request{
+		Method:        raw.Method.Value,
+		MethodMatcher: raw.Method.Matcher,
+		Path:          raw.Path.Value,
+		PathMatcher:   raw.Path.Matcher,
+		QueryParams:   map[string][]string(raw.QueryParams),
+		Headers:       map[string][]string(raw.Headers),
+	}
+
+	if raw.Body != nil && raw.Body.Matcher != "" {
+		body := RequestBody(*raw.Body)
+		r.Body = &body
+	}
+
+	return nil
+}
+
+// stringMatcher Accepts either a plain string or a Smocker string matcher object. ShouldEqual, the matcher Smocker uses
+// for plain strings, is kept as an empty matcher.
+type stringMatcher RequestBody
+
+func (sm *stringMatcher) UnmarshalJSON(data []byte) error {
+	var value string
+	err := json.Unmarshal(data, &value)
+	if err == nil {
+		*sm = stringMatcher{Value: value}
+		return nil
+	}
+
+	var matcher RequestBody
+	err = json.Unmarshal(data, &matcher)
+	if err != nil {
+		return fmt.Errorf("expected a string or a matcher object but got %s", data)
+	}
+
+	if matcher.Matcher == "ShouldEqual" {
+		matcher.Matcher = ""
+	}
+
+	*sm = stringMatcher(matcher)
+	return nil
+}
+
+// stringMatchers Accepts a single value or a list of values, where each value is a plain string or a matcher object.
+type stringMatchers []string
+
+func (sm *stringMatchers) UnmarshalJSON(data []byte) error {
+	var list []stringMatcher
+	err := json.Unmarshal(data, &list)
+	if err != nil {
+		var single stringMatcher
+		err = json.Unmarshal(data, &single)
+		if err != nil {
+			return err
+		}
+		list = []stringMatcher{single}
+	}
+
+	values := make([]string, 0, len(list))
+	for _, value := range list {
+		values = append(values, value.Value)
+	}
+
+	*sm = values
+	return nil
+}
+
+// multiMapMatcher Accepts a map of keys to values, optionally wrapped in a Smocker matcher object of the form
+// {"matcher": "ShouldContainSubset", "values": {...}}.
+type multiMapMatcher map[string][]string
+
+func (mm *multiMapMatcher) UnmarshalJSON(data []byte) error {
+	var wrapped struct {
+		Matcher string                    `json:"matcher"`
+		Values  map[string]stringMatchers `json:"values"`
+	}
+	err := json.Unmarshal(data, &wrapped)
+	if err == nil && wrapped.Matcher != "" && wrapped.Values != nil {
+		*mm = toMultiMap(wrapped.Values)
+		return nil
+	}
+
+	var values map[string]stringMatchers
+	err = json.Unmarshal(data, &values)
+	if err != nil {
+		return err
+	}
+
+	*mm = toMultiMap(values)
+	return nil
+}
+
+func toMultiMap(values map[string]stringMatchers) multiMapMatcher {
+	if values == nil {
+		return nil
+	}
+
+	multiMap := make(multiMapMatcher, len(values))
+	for key, value := range values {
+		multiMap[key] = value
+	}
+
+	return multiMap
+}
+
+// bodyMatcher Accepts a plain string body, which Smocker treats as ShouldEqual, or a single matcher object. Bodies made
+// of json path matchers are ignored as they cannot be represented by RequestBody.
+type bodyMatcher RequestBody
+
+func (bm *bodyMatcher) UnmarshalJSON(data []byte) error {
+	var value string
+	err := json.Unmarshal(data, &value)
+	if err == nil {
+		*bm = bodyMatcher{Matcher: "ShouldEqual", Value: value}
+		return nil
+	}
+
+	var matcher struct {
+		Matcher string          `json:"matcher"`
+		Value   json.RawMessage `json:"value"`
+	}
+	err = json.Unmarshal(data, &matcher)
+	if err != nil {
+		return err
+	}
+
+	var matcherValue string
+	err = json.Unmarshal(matcher.Value, &matcherValue)
+	if err != nil {
+		*bm = bodyMatcher{}
+		return nil
+	}
+
+	*bm = bodyMatcher{Matcher: matcher.Matcher, Value: matcherValue}
+	return nil
+}
@@ -0,0 +1,116 @@
+package mock_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/churmd/smockerclient/mock"
+)
+
+func TestRequest_UnmarshalJSON(t *testing.T) {
+	t.Run("it decodes the matchers returned by the smocker server", func(t *testing.T) {
+		smockerJson := `{
+			"method": {"matcher": "ShouldEqual", "value": "PUT"},
+			"path": {"matcher": "ShouldEqual", "value": "/foo/bar"},
+			"query_params": {
+				"limit": [{"matcher": "ShouldEqual", "value": "10"}],
+				"filters": [{"matcher": "ShouldEqual", "value": "red"}, {"matcher": "ShouldEqual", "value": "green"}]
+			},
+			"headers": {
+				"Content-Type": [{"matcher": "ShouldEqual", "value": "application/json"}, {"matcher": "ShouldEqual", "value": "application/vnd.api+json"}],
+				"Authorization": [{"matcher": "ShouldEqual", "value": "Bearer sv2361fr1o8ph3oin"}]
+			},
+			"body": {
+				"matcher": "ShouldEqualJSON",
+				"value": "{\"name\": \"John Smith\", \"uuid\": \"daa7b90d-9429-4d7a-9304-edc41ff44a6d\", \"rank\": 10}"
+			}
+		}`
+
+		var request mock.Request
+		err := json.Unmarshal([]byte(smockerJson), &request)
+
+		assert.NoError(t, err)
+		assert.Equal(t, createRequest(), request)
+	})
+
+	t.Run("it decodes the format it encodes to", func(t *testing.T) {
+		expected := createRequest()
+		encoded, err := json.Marshal(expected)
+		assert.NoError(t, err)
+
+		var request mock.Request
+		err = json.Unmarshal(encoded, &request)
+
+		assert.NoError(t, err)
+		assert.Equal(t, expected, request)
+	})
+
+	t.Run("it decodes headers wrapped in a multi map matcher", func(t *testing.T) {
+		smockerJson := `{
+			"method": "GET",
+			"path": "/example",
+			"headers": {
+				"matcher": "ShouldContainSubset",
+				"values": {"Accept": ["application/json"]}
+			}
+		}`
+
+		var request mock.Request
+		err := json.Unmarshal([]byte(smockerJson), &request)
+
+		expected := mock.NewRequestBuilder(http.MethodGet, "/example").
+			AddHeader("Accept", "application/json").
+			Build()
+		assert.NoError(t, err)
+		assert.Equal(t, expected, request)
+	})
+
+	t.Run("it ignores json path body matchers", func(t *testing.T) {
+		smockerJson := `{
+			"method": "POST",
+			"path": "/example",
+			"body": {
+				"name": {"matcher": "ShouldEqual", "value": "John Smith"}
+			}
+		}`
+
+		var request mock.Request
+		err := json.Unmarshal([]byte(smockerJson), &request)
+
+		assert.NoError(t, err)
+		assert.Equal(t, mock.NewRequestBuilder(http.MethodPost, "/example").Build(), request)
+	})
+
+	t.Run("it keeps the method and path matchers other than ShouldEqual", func(t *testing.T) {
+		smockerJson := `{
+			"method": {"matcher": "ShouldMatch", "value": "POST|PUT"},
+			"path": {"matcher": "ShouldMatch", "value": "/orders/.*"}
+		}`
+
+		var request mock.Request
+		err := json.Unmarshal([]byte(smockerJson), &request)
+
+		expected := mock.Request{
+			Method:        "POST|PUT",
+			MethodMatcher: "ShouldMatch",
+			Path:          "/orders/.*",
+			PathMatcher:   "ShouldMatch",
+		}
+		assert.NoError(t, err)
+		assert.Equal(t, expected, request)
+
+		encoded, err := json.Marshal(request)
+		assert.NoError(t, err)
+		assert.JSONEq(t, smockerJson, string(encoded))
+	})
+
+	t.Run("it returns an error when the path is not a string or matcher", func(t *testing.T) {
+		var request mock.Request
+		err := json.Unmarshal([]byte(`{"method": "GET", "path": 1234}`), &request)
+
+		assert.Error(t, err)
+	})
+}
@@ -2,14 +2,49 @@ package mock
 
 import "encoding/json"
 
+// JsonDefinition Allows multiple styles of mock creation to be used and custom extension.
+// ToMockDefinitionJson must return json conforming to the smocker mock definition
+// https://smocker.dev/technical-documentation/mock-definition.html as bytes.
+// It is the same type as smockerclient.MockDefinition, declared here so the mock package does not import smockerclient.
+type JsonDefinition interface {
+	ToMockDefinitionJson() ([]byte, error)
+}
+
 type Request struct {
-	Method      string              `json:"method"`
-	Path        string              `json:"path"`
+	Method string `json:"method"`
+	// MethodMatcher The Smocker matcher the method is compared with, e.g. ShouldMatch. Empty means ShouldEqual.
+	MethodMatcher string `json:"-"`
+	Path          string `json:"path"`
+	// PathMatcher The Smocker matcher the path is compared with, e.g. ShouldMatch. Empty means ShouldEqual.
+	PathMatcher string              `json:"-"`
 	QueryParams map[string][]string `json:"query_params,omitempty"`
 	Headers     map[string][]string `json:"headers,omitempty"`
 	Body        *RequestBody        `json:"body,omitempty"`
 }
 
+// MarshalJSON Encodes the request in the smocker mock definition format. The method and path are plain strings unless
+// they use a matcher other than ShouldEqual.
+func (r Request) MarshalJSON() ([]byte, error) {
+	type plainRequest Request
+	return json.Marshal(struct {
+		Method any `json:"method"`
+		Path   any `json:"path"`
+		plainRequest
+	}{
+		Method:       encodeStringMatcher(r.MethodMatcher, r.Method),
+		Path:         encodeStringMatcher(r.PathMatcher, r.Path),
+		plainRequest: plainRequest(r),
+	})
+}
+
+func encodeStringMatcher(matcher, value string) any {
+	if matcher == "" {
+		return value
+	}
+
+	return RequestBody{Matcher: matcher, Value: value}
+}
+
 type RequestBody struct {
 	Matcher string `json:"matcher"`
 	Value   string `json:"value"`
@@ -1,12 +1,12 @@
 package mock
 
-import "github.com/churmd/smockerclient"
-
 type RawJsonDefinition struct {
 	json string
 }
 
-func NewRawJsonDefinition(json string) smockerclient.MockDefinition {
+// NewRawJsonDefinition Creates a mock definition from json conforming to the smocker mock definition
+// https://smocker.dev/technical-documentation/mock-definition.html
+func NewRawJsonDefinition(json string) JsonDefinition {
 	return RawJsonDefinition{
 		json: json,
 	}
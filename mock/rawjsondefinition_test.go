@@ -5,6 +5,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/churmd/smockerclient"
 	"github.com/churmd/smockerclient/mock"
 )
 
@@ -18,6 +19,15 @@ func TestRawJsonDefinition_ToMockJson(t *testing.T) {
 	assert.JSONEq(t, json, string(definition))
 }
 
+func TestNewRawJsonDefinition_ReturnsMockDefinition(t *testing.T) {
+	var newDefinition func(json string) smockerclient.MockDefinition = mock.NewRawJsonDefinition
+
+	definition, err := newDefinition(jsonForMock()).ToMockDefinitionJson()
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, jsonForMock(), string(definition))
+}
+
 func jsonForMock() string {
 	return `{
 	   "request": {
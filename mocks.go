@@ -0,0 +1,216 @@
+package smockerclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/churmd/smockerclient/mock"
+)
+
+// Mock A mock registered on the Smocker server along with its usage state.
+type Mock struct {
+	Definition mock.Definition
+	State      MockState
+}
+
+type MockState struct {
+	ID           string    `json:"id"`
+	TimesCount   int       `json:"times_count"`
+	Locked       bool      `json:"locked"`
+	CreationDate time.Time `json:"creation_date"`
+}
+
+type smockerMock struct {
+	mock.Definition
+	State MockState `json:"state"`
+}
+
+// UnmarshalJSON Decodes a mock in the format returned by the Smocker server, where the state is held alongside the
+// mock definition.
+func (m *Mock) UnmarshalJSON(data []byte) error {
+	var raw smockerMock
+	err := json.Unmarshal(data, &raw)
+	if err != nil {
+		return err
+	}
+
+	*m = Mock{
+		Definition: raw.Definition,
+		State:      raw.State,
+	}
+
+	return nil
+}
+
+// MarshalJSON Encodes the mock in the format used by the Smocker server.
+func (m Mock) MarshalJSON() ([]byte, error) {
+	return json.Marshal(smockerMock{
+		Definition: m.Definition,
+		State:      m.State,
+	})
+}
+
+// GetMocks Gets the mocks registered in the session with the given id, along with how many times each has been called
+// and whether it is locked. An empty session id gets the mocks of the latest session.
+func (i Instance) GetMocks(sessionID string) ([]Mock, error) {
+	return i.GetMocksContext(context.Background(), sessionID)
+}
+
+// GetMocksContext Is GetMocks with a context to cancel the request and set its deadline.
+func (i Instance) GetMocksContext(ctx context.Context, sessionID string) ([]Mock, error) {
+	resp, err := i.sendGetMocksRequest(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("smockerclient unable to get the mocks of session %s. %w", sessionID, err)
+	}
+	defer resp.Body.Close()
+
+	err = handleNon200Response(resp)
+	if err != nil {
+		return nil, fmt.Errorf("smockerclient unable to get the mocks of session %s. %w", sessionID, err)
+	}
+
+	var mocks []Mock
+	err = json.NewDecoder(resp.Body).Decode(&mocks)
+	if err != nil {
+		return nil, fmt.Errorf("smockerclient unable to read json response to get the mocks of session %s. %w", sessionID, err)
+	}
+
+	return mocks, nil
+}
+
+func (i Instance) sendGetMocksRequest(ctx context.Context, sessionID string) (*http.Response, error) {
+	req, err := i.createGetMocksRequest(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create request. %w", err)
+	}
+
+	resp, err := i.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("unable to send request. %w", err)
+	}
+
+	return resp, nil
+}
+
+func (i Instance) createGetMocksRequest(ctx context.Context, sessionID string) (*http.Request, error) {
+	url, err := i.endpoint("/mocks")
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	addSessionQueryParam(req, sessionID)
+	return req, nil
+}
+
+// newestMockIDs Gets the ids of the most recently created mocks in the session, oldest first. Smocker does not return
+// the ids of the mocks it creates, so they are looked up after the mocks have been added.
+func (i Instance) newestMockIDs(ctx context.Context, sessionID string, count int) ([]string, error) {
+	mocks, err := i.GetMocksContext(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(mocks) < count {
+		return nil, fmt.Errorf("expected at least %d mocks in the session but found %d", count, len(mocks))
+	}
+
+	sort.SliceStable(mocks, func(a, b int) bool {
+		return mocks[a].State.CreationDate.After(mocks[b].State.CreationDate)
+	})
+
+	ids := make([]string, count)
+	for index := range ids {
+		ids[count-1-index] = mocks[index].State.ID
+	}
+
+	return ids, nil
+}
+
+// LockMocks Locks the mocks with the given ids, so they are kept when the sessions and mocks are reset.
+func (i Instance) LockMocks(ids ...string) error {
+	return i.LockMocksContext(context.Background(), ids...)
+}
+
+// LockMocksContext Is LockMocks with a context to cancel the request and set its deadline.
+func (i Instance) LockMocksContext(ctx context.Context, ids ...string) error {
+	err := i.changeMocksLock(ctx, "lock", ids)
+	if err != nil {
+		return fmt.Errorf("smockerclient unable to lock mocks %v. %w", ids, err)
+	}
+
+	return nil
+}
+
+// UnlockMocks Unlocks the mocks with the given ids, so they are removed the next time the sessions and mocks are reset.
+func (i Instance) UnlockMocks(ids ...string) error {
+	return i.UnlockMocksContext(context.Background(), ids...)
+}
+
+// UnlockMocksContext Is UnlockMocks with a context to cancel the request and set its deadline.
+func (i Instance) UnlockMocksContext(ctx context.Context, ids ...string) error {
+	err := i.changeMocksLock(ctx, "unlock", ids)
+	if err != nil {
+		return fmt.Errorf("smockerclient unable to unlock mocks %v. %w", ids, err)
+	}
+
+	return nil
+}
+
+func (i Instance) changeMocksLock(ctx context.Context, action string, ids []string) error {
+	resp, err := i.sendMocksLockRequest(ctx, action, ids)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return handleNon200Response(resp)
+}
+
+func (i Instance) sendMocksLockRequest(ctx context.Context, action string, ids []string) (*http.Response, error) {
+	req, err := i.createMocksLockRequest(ctx, action, ids)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create request. %w", err)
+	}
+
+	resp, err := i.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("unable to send request. %w", err)
+	}
+
+	return resp, nil
+}
+
+func (i Instance) createMocksLockRequest(ctx context.Context, action string, ids []string) (*http.Request, error) {
+	if ids == nil {
+		ids = []string{}
+	}
+
+	body := &bytes.Buffer{}
+	err := json.NewEncoder(body).Encode(ids)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create request body bytes from mock ids. %w", err)
+	}
+
+	url, err := i.endpoint("/mocks/" + action)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", "application/json")
+	return req, nil
+}
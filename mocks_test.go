@@ -0,0 +1,234 @@
+package smockerclient_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/churmd/smockerclient"
+	"github.com/churmd/smockerclient/mock"
+)
+
+func TestGetMocks(t *testing.T) {
+	serverCallCount := 0
+	sessionID := "Z9gF5kwSR"
+
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				serverCallCount++
+
+				assert.Equal(t, http.MethodGet, r.Method)
+				assert.Equal(t, "/mocks", r.URL.Path)
+				assert.Equal(t, sessionID, r.URL.Query().Get("session"))
+
+				_, err := w.Write([]byte(getMocksBody()))
+				assert.NoError(t, err, "httptest server write failed")
+			},
+		),
+	)
+	defer server.Close()
+
+	smockerInstance := smockerclient.Instance{Url: server.URL}
+	mocks, err := smockerInstance.GetMocks(sessionID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, serverCallCount)
+
+	lockedRequest := mock.NewRequestBuilder(http.MethodGet, "/healthcheck").Build()
+	lockedResponse := mock.NewResponseBuilder(http.StatusOK).Build()
+	limitedRequest := mock.NewRequestBuilder(http.MethodPost, "/orders").
+		AddJsonBody(`{"id": 1234}`).
+		Build()
+	limitedResponse := mock.NewResponseBuilder(http.StatusCreated).Build()
+	expected := []smockerclient.Mock{
+		{
+			Definition: mock.NewDefinition(lockedRequest, lockedResponse, mock.WithCallLimit(0)),
+			State: smockerclient.MockState{
+				ID:           "lkd8ks4Rz",
+				TimesCount:   12,
+				Locked:       true,
+				CreationDate: time.Date(2023, 4, 26, 14, 41, 44, 0, time.UTC),
+			},
+		},
+		{
+			Definition: mock.NewDefinition(limitedRequest, limitedResponse, mock.WithCallLimit(1)),
+			State: smockerclient.MockState{
+				ID:           "bqeh8ks4R",
+				TimesCount:   1,
+				Locked:       false,
+				CreationDate: time.Date(2023, 4, 26, 14, 41, 43, 0, time.UTC),
+			},
+		},
+	}
+	assert.Equal(t, expected, mocks)
+}
+
+func TestGetMocks_WhenServerDoesNotReturn200_ReturnsError(t *testing.T) {
+	server, serverCallCount := newBadResponseServer(t)
+	defer server.Close()
+
+	smockerInstance := smockerclient.Instance{Url: server.URL}
+	mocks, err := smockerInstance.GetMocks("Z9gF5kwSR")
+
+	assert.Equal(t, 1, *serverCallCount)
+	assert.Nil(t, mocks)
+	assert.EqualError(t, err, "smockerclient unable to get the mocks of session Z9gF5kwSR. received status:400 and message:400 Bad Request")
+}
+
+func TestMock_MarshalJSON_UsesSmockerFormat(t *testing.T) {
+	request := mock.NewRequestBuilder(http.MethodGet, "/healthcheck").Build()
+	response := mock.NewResponseBuilder(http.StatusOK).Build()
+	smockerMock := smockerclient.Mock{
+		Definition: mock.NewDefinition(request, response),
+		State: smockerclient.MockState{
+			ID:           "lkd8ks4Rz",
+			TimesCount:   2,
+			Locked:       true,
+			CreationDate: time.Date(2023, 4, 26, 14, 41, 44, 0, time.UTC),
+		},
+	}
+
+	actualJson, err := json.Marshal(smockerMock)
+
+	expectedJson := `{
+		"request": {"method": "GET", "path": "/healthcheck"},
+		"response": {"status": 200},
+		"state": {
+			"id": "lkd8ks4Rz",
+			"times_count": 2,
+			"locked": true,
+			"creation_date": "2023-04-26T14:41:44Z"
+		}
+	}`
+	assert.NoError(t, err)
+	assert.JSONEq(t, expectedJson, string(actualJson))
+}
+
+func getMocksBody() string {
+	return `[
+    {
+        "request": {
+            "path": {"matcher": "ShouldEqual", "value": "/healthcheck"},
+            "method": {"matcher": "ShouldEqual", "value": "GET"}
+        },
+        "response": {
+            "status": 200,
+            "delay": {}
+        },
+        "context": {},
+        "state": {
+            "id": "lkd8ks4Rz",
+            "times_count": 12,
+            "locked": true,
+            "creation_date": "2023-04-26T14:41:44Z"
+        }
+    },
+    {
+        "request": {
+            "path": {"matcher": "ShouldEqual", "value": "/orders"},
+            "method": {"matcher": "ShouldEqual", "value": "POST"},
+            "body": {"matcher": "ShouldEqualJSON", "value": "{\"id\": 1234}"}
+        },
+        "response": {
+            "status": 201,
+            "delay": {}
+        },
+        "context": {
+            "times": 1
+        },
+        "state": {
+            "id": "bqeh8ks4R",
+            "times_count": 1,
+            "locked": false,
+            "creation_date": "2023-04-26T14:41:43Z"
+        }
+    }
+]`
+}
+
+func TestLockMocks(t *testing.T) {
+	serverCallCount := 0
+
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				serverCallCount++
+
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Equal(t, "/mocks/lock", r.URL.Path)
+				assert.Equal(t, jsonContentType, r.Header.Get("Content-Type"))
+
+				body, err := io.ReadAll(r.Body)
+				assert.NoError(t, err)
+				assert.JSONEq(t, `["lkd8ks4Rz", "bqeh8ks4R"]`, string(body))
+
+				_, err = w.Write([]byte(getMocksBody()))
+				assert.NoError(t, err, "httptest server write failed")
+			},
+		),
+	)
+	defer server.Close()
+
+	smockerInstance := smockerclient.Instance{Url: server.URL}
+	err := smockerInstance.LockMocks("lkd8ks4Rz", "bqeh8ks4R")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, serverCallCount)
+}
+
+func TestLockMocks_WhenServerDoesNotReturn200_ReturnsError(t *testing.T) {
+	server, serverCallCount := newBadResponseServer(t)
+	defer server.Close()
+
+	smockerInstance := smockerclient.Instance{Url: server.URL}
+	err := smockerInstance.LockMocks("lkd8ks4Rz")
+
+	assert.Equal(t, 1, *serverCallCount)
+	assert.EqualError(t, err, "smockerclient unable to lock mocks [lkd8ks4Rz]. received status:400 and message:400 Bad Request")
+}
+
+func TestUnlockMocks(t *testing.T) {
+	serverCallCount := 0
+
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				serverCallCount++
+
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Equal(t, "/mocks/unlock", r.URL.Path)
+
+				body, err := io.ReadAll(r.Body)
+				assert.NoError(t, err)
+				assert.JSONEq(t, `["lkd8ks4Rz"]`, string(body))
+
+				_, err = w.Write([]byte(getMocksBody()))
+				assert.NoError(t, err, "httptest server write failed")
+			},
+		),
+	)
+	defer server.Close()
+
+	smockerInstance := smockerclient.Instance{Url: server.URL}
+	err := smockerInstance.UnlockMocks("lkd8ks4Rz")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, serverCallCount)
+}
+
+func TestUnlockMocks_WhenServerDoesNotReturn200_ReturnsError(t *testing.T) {
+	server, serverCallCount := newBadResponseServer(t)
+	defer server.Close()
+
+	smockerInstance := smockerclient.Instance{Url: server.URL}
+	err := smockerInstance.UnlockMocks("lkd8ks4Rz")
+
+	assert.Equal(t, 1, *serverCallCount)
+	assert.EqualError(t, err, "smockerclient unable to unlock mocks [lkd8ks4Rz]. received status:400 and message:400 Bad Request")
+}
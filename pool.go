@@ -0,0 +1,60 @@
+package smockerclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// TestingT The parts of testing.T used to lease an Instance for the length of a test.
+type TestingT interface {
+	Helper()
+	Cleanup(func())
+	Fatalf(format string, args ...any)
+}
+
+// InstancePool Hands out exclusive leases on a set of Instances, e.g. one per Smocker container, so parallel tests do
+// not share the latest session of a Smocker server. Create it with NewInstancePool.
+type InstancePool struct {
+	free chan Instance
+}
+
+// NewInstancePool Creates a pool leasing out the given instances.
+func NewInstancePool(instances ...Instance) *InstancePool {
+	free := make(chan Instance, len(instances))
+	for _, instance := range instances {
+		free <- instance
+	}
+
+	return &InstancePool{free: free}
+}
+
+// Acquire Leases an Instance for the rest of the test, waiting until one is free. The Instance is returned to the pool
+// when the test and its subtests have finished.
+func (p *InstancePool) Acquire(t TestingT) Instance {
+	t.Helper()
+
+	instance, err := p.AcquireContext(context.Background(), t)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	return instance
+}
+
+// AcquireContext Is Acquire with a context to stop waiting for a free Instance.
+func (p *InstancePool) AcquireContext(ctx context.Context, t TestingT) (Instance, error) {
+	t.Helper()
+
+	if cap(p.free) == 0 {
+		return Instance{}, errors.New("smockerclient unable to acquire an instance. the pool has no instances")
+	}
+
+	select {
+	case instance := <-p.free:
+		t.Cleanup(func() { p.free <- instance })
+		return instance, nil
+	case <-ctx.Done():
+		return Instance{}, fmt.Errorf("smockerclient unable to acquire an instance. %w", ctx.Err())
+	}
+}
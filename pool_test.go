@@ -0,0 +1,108 @@
+package smockerclient_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/churmd/smockerclient"
+)
+
+func TestInstancePool_LeasesEachInstanceExclusively(t *testing.T) {
+	first := smockerclient.Instance{Url: "http://localhost:8081"}
+	second := smockerclient.Instance{Url: "http://localhost:8082"}
+	pool := smockerclient.NewInstancePool(first, second)
+
+	fakeT := &fakeTestingT{}
+	leased := []smockerclient.Instance{pool.Acquire(fakeT), pool.Acquire(fakeT)}
+
+	assert.ElementsMatch(t, []smockerclient.Instance{first, second}, leased)
+	assert.Len(t, fakeT.cleanups, 2)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := pool.AcquireContext(ctx, &fakeTestingT{})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.EqualError(t, err, "smockerclient unable to acquire an instance. context deadline exceeded")
+}
+
+func TestInstancePool_WhenAllInstancesAreLeased_WaitsForCleanup(t *testing.T) {
+	instance := smockerclient.Instance{Url: "http://localhost:8081"}
+	pool := smockerclient.NewInstancePool(instance)
+
+	fakeT := &fakeTestingT{}
+	pool.Acquire(fakeT)
+
+	acquired := make(chan smockerclient.Instance)
+	go func() {
+		acquired <- pool.Acquire(&fakeTestingT{})
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("instance acquired while it was still leased")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	fakeT.runCleanups()
+
+	select {
+	case leased := <-acquired:
+		assert.Equal(t, instance, leased)
+	case <-time.After(time.Second):
+		t.Fatal("instance not acquired after it was released")
+	}
+}
+
+func TestInstancePool_ReleasesInstanceWhenTestFinishes(t *testing.T) {
+	pool := smockerclient.NewInstancePool(smockerclient.Instance{})
+
+	for i := range 3 {
+		t.Run(fmt.Sprintf("test %d", i), func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+
+			_, err := pool.AcquireContext(ctx, t)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestInstancePool_WhenPoolIsEmpty_FailsTheTest(t *testing.T) {
+	pool := smockerclient.NewInstancePool()
+
+	fakeT := &fakeTestingT{}
+	pool.Acquire(fakeT)
+
+	assert.Equal(t, []string{"smockerclient unable to acquire an instance. the pool has no instances"}, fakeT.failures)
+}
+
+type fakeTestingT struct {
+	cleanups []func()
+	failures []string
+}
+
+func (f *fakeTestingT) Helper() {}
+
+func (f *fakeTestingT) Cleanup(cleanup func()) {
+	f.cleanups = append(f.cleanups, cleanup)
+}
+
+func (f *fakeTestingT) Fatalf(format string, args ...any) {
+	f.failures = append(f.failures, fmt.Sprintf(format, args...))
+}
+
+func (f *fakeTestingT) runCleanups() {
+	for i := len(f.cleanups) - 1; i >= 0; i-- {
+		f.cleanups[i]()
+	}
+	f.cleanups = nil
+}
+
+func (f *fakeTestingT) Errorf(format string, args ...any) {
+	f.failures = append(f.failures, fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,163 @@
+package smockerclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"time"
+)
+
+const (
+	defaultRetryInitialBackoff = 100 * time.Millisecond
+	defaultRetryMaxBackoff     = 2 * time.Second
+)
+
+// RetryPolicy How requests to the Smocker server are retried when it is briefly unavailable, e.g. while its container
+// restarts. Only connection errors and 502 Bad Gateway or 503 Service Unavailable responses are retried. Requests that
+// change the server, such as adding mocks, are only retried when the connection could not be opened or on a 503, as a
+// proxy may have passed them on before answering 502, so they are never sent twice. Any other response, including a
+// failed verification, is returned straight away. The zero value sends each request once.
+type RetryPolicy struct {
+	// MaxAttempts The most times a request is sent, including the first attempt. Values below 2 disable retrying.
+	MaxAttempts int
+	// InitialBackoff The delay before the first retry, doubled for each retry after. Defaults to 100ms.
+	InitialBackoff time.Duration
+	// MaxBackoff The longest delay between retries. Defaults to 2s.
+	MaxBackoff time.Duration
+}
+
+func (p RetryPolicy) initialBackoff() time.Duration {
+	if p.InitialBackoff <= 0 {
+		return defaultRetryInitialBackoff
+	}
+
+	return p.InitialBackoff
+}
+
+func (p RetryPolicy) maxBackoff() time.Duration {
+	if p.MaxBackoff <= 0 {
+		return defaultRetryMaxBackoff
+	}
+
+	return p.MaxBackoff
+}
+
+// backoff The delay before the given retry, starting at 1. Jitter picks a random delay between half and all of the
+// exponential backoff so clients retrying at the same time spread out.
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	backoff := p.initialBackoff()
+	for n := 1; n < retry && backoff < p.maxBackoff(); n++ {
+		backoff *= 2
+	}
+	backoff = min(backoff, p.maxBackoff())
+
+	half := backoff / 2
+	return half + rand.N(backoff-half+1)
+}
+
+// isRetryable Reports whether the request can be sent again. Any connection error or 502 is retried for GET and HEAD
+// requests, other requests could create a duplicate mock or session if they reached the server before the connection
+// failed or the proxy gave up on it, so they are only retried when the connection could not be opened or on a 503.
+func isRetryable(req *http.Request, resp *http.Response, err error) bool {
+	safe := req.Method == http.MethodGet || req.Method == http.MethodHead
+
+	if err != nil {
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return false
+		}
+
+		if safe {
+			return true
+		}
+
+		var opErr *net.OpError
+		return errors.As(err, &opErr) && opErr.Op == "dial"
+	}
+
+	return resp.StatusCode == http.StatusServiceUnavailable || (safe && resp.StatusCode == http.StatusBadGateway)
+}
+
+// do Sends the request to the Smocker admin server with the Instance's Auth, retrying it as set by the Instance's
+// RetryPolicy. Errors sending the request are marked with ErrServerUnreachable.
+func (i Instance) do(req *http.Request) (*http.Response, error) {
+	if i.Auth != nil {
+		i.Auth.Authenticate(req)
+	}
+
+	for attempt := 1; ; attempt++ {
+		resp, err := i.send(req)
+		if attempt >= i.Retry.MaxAttempts || !isRetryable(req, resp, err) || !canResend(req) {
+			if err != nil && attempt > 1 {
+				return nil, fmt.Errorf("gave up after %d attempts. %w", attempt, err)
+			}
+			return resp, err
+		}
+
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(i.Retry.backoff(attempt))
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, fmt.Errorf("%w. last error: %w", req.Context().Err(), retryCause(resp, err))
+		case <-timer.C:
+		}
+
+		req, err = rewind(req)
+		if err != nil {
+			return nil, fmt.Errorf("unable to resend request. %w", err)
+		}
+	}
+}
+
+// send Sends the request to the Smocker server once and logs it when the Instance has a Logger. Errors sending the
+// request are marked with ErrServerUnreachable. The response always holds the request, as a custom RoundTripper in the
+// HttpClient is not required to set it.
+func (i Instance) send(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := i.httpClient().Do(req)
+	i.logRequest(req, resp, err, time.Since(start))
+	if err != nil {
+		return nil, markUnreachable(err)
+	}
+
+	if resp.Request == nil {
+		resp.Request = req
+	}
+
+	return resp, nil
+}
+
+func canResend(req *http.Request) bool {
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+func rewind(req *http.Request) (*http.Request, error) {
+	if req.GetBody == nil {
+		return req, nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+
+	rewound := req.Clone(req.Context())
+	rewound.Body = body
+	return rewound, nil
+}
+
+func retryCause(resp *http.Response, err error) error {
+	if err != nil {
+		return err
+	}
+
+	return fmt.Errorf("received status:%d", resp.StatusCode)
+}
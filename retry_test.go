@@ -0,0 +1,211 @@
+package smockerclient_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/churmd/smockerclient"
+)
+
+var fastRetry = smockerclient.RetryPolicy{
+	MaxAttempts:    3,
+	InitialBackoff: time.Millisecond,
+	MaxBackoff:     5 * time.Millisecond,
+}
+
+func TestRetry_WhenServerIsBrieflyUnavailable_RetriesUntilSuccess(t *testing.T) {
+	server, serverCallCount := newUnavailableServer(t, 2, http.StatusServiceUnavailable)
+	defer server.Close()
+
+	smockerInstance := smockerclient.Instance{Url: server.URL, Retry: fastRetry}
+	err := smockerInstance.ResetAllSessionsAndMocks()
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, *serverCallCount)
+}
+
+func TestRetry_WhenServerReturnsBadGatewayForGet_Retries(t *testing.T) {
+	server, serverCallCount := newUnavailableServer(t, 1, http.StatusBadGateway)
+	defer server.Close()
+
+	smockerInstance := smockerclient.Instance{Url: server.URL, Retry: fastRetry}
+	err := smockerInstance.ExportSessions(io.Discard)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, *serverCallCount)
+}
+
+func TestRetry_WhenServerReturnsBadGatewayForPost_DoesNotRetry(t *testing.T) {
+	server, serverCallCount := newUnavailableServer(t, 1, http.StatusBadGateway)
+	defer server.Close()
+
+	smockerInstance := smockerclient.Instance{Url: server.URL, Retry: fastRetry}
+	err := smockerInstance.ResetAllSessionsAndMocks()
+
+	var statusErr *smockerclient.StatusError
+	assert.ErrorAs(t, err, &statusErr)
+	assert.Equal(t, http.StatusBadGateway, statusErr.StatusCode)
+	assert.Equal(t, 1, *serverCallCount)
+}
+
+func TestRetry_WhenServerStaysUnavailable_ReturnsLastStatusError(t *testing.T) {
+	server, serverCallCount := newUnavailableServer(t, 5, http.StatusServiceUnavailable)
+	defer server.Close()
+
+	smockerInstance := smockerclient.Instance{Url: server.URL, Retry: fastRetry}
+	err := smockerInstance.ResetAllSessionsAndMocks()
+
+	var statusErr *smockerclient.StatusError
+	assert.ErrorAs(t, err, &statusErr)
+	assert.Equal(t, http.StatusServiceUnavailable, statusErr.StatusCode)
+	assert.Equal(t, 3, *serverCallCount)
+}
+
+func TestRetry_WhenServerIsUnavailable_ResendsRequestBody(t *testing.T) {
+	serverCallCount := 0
+
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				serverCallCount++
+
+				body, err := io.ReadAll(r.Body)
+				assert.NoError(t, err)
+				assert.JSONEq(t, `{"id": "Z9gF5kwSR", "name": "PASSED my-session"}`, string(body))
+
+				if serverCallCount == 1 {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+
+				_, err = w.Write([]byte(`{"id": "Z9gF5kwSR", "name": "PASSED my-session"}`))
+				assert.NoError(t, err, "httptest server write failed")
+			},
+		),
+	)
+	defer server.Close()
+
+	smockerInstance := smockerclient.Instance{Url: server.URL, Retry: fastRetry}
+	session, err := smockerInstance.UpdateSession("Z9gF5kwSR", "PASSED my-session")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "PASSED my-session", session.Name)
+	assert.Equal(t, 2, serverCallCount)
+}
+
+func TestRetry_WhenConnectionIsDroppedOnGet_Retries(t *testing.T) {
+	server, serverCallCount := newDroppingServer(t, 1)
+	defer server.Close()
+
+	smockerInstance := smockerclient.Instance{Url: server.URL, Retry: fastRetry}
+	_, err := smockerInstance.GetHistory("Z9gF5kwSR")
+
+	assert.EqualError(t, err, "smockerclient unable to get the history of session Z9gF5kwSR. received status:404 and message:")
+	assert.EqualValues(t, 2, serverCallCount.Load())
+}
+
+func TestRetry_WhenConnectionIsDroppedAfterPostIsSent_DoesNotRetry(t *testing.T) {
+	server, serverCallCount := newDroppingServer(t, 1)
+	defer server.Close()
+
+	smockerInstance := smockerclient.Instance{Url: server.URL, Retry: fastRetry}
+	err := smockerInstance.StartSession("my-session")
+
+	assert.ErrorIs(t, err, smockerclient.ErrServerUnreachable)
+	assert.EqualValues(t, 1, serverCallCount.Load())
+}
+
+func TestRetry_WhenServerIsNotRunning_ReturnsErrServerUnreachableAfterAllAttempts(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	smockerInstance := smockerclient.Instance{Url: server.URL, Retry: fastRetry}
+	err := smockerInstance.ResetAllSessionsAndMocks()
+
+	assert.ErrorIs(t, err, smockerclient.ErrServerUnreachable)
+	assert.ErrorContains(t, err, "smockerclient unable to reset all the sessions and mocks. unable to send request. gave up after 3 attempts. Post")
+}
+
+func TestRetry_WhenServerReturnsOtherErrorStatus_DoesNotRetry(t *testing.T) {
+	server, serverCallCount := newUnavailableServer(t, 5, http.StatusInternalServerError)
+	defer server.Close()
+
+	smockerInstance := smockerclient.Instance{Url: server.URL, Retry: fastRetry}
+	err := smockerInstance.ResetAllSessionsAndMocks()
+
+	assert.Error(t, err)
+	assert.Equal(t, 1, *serverCallCount)
+}
+
+func TestRetry_WhenVerificationFails_DoesNotRetry(t *testing.T) {
+	serverCallCount := 0
+
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				serverCallCount++
+				_, err := w.Write([]byte(getVerifyBodyWhenSomeMocksAreNotCalled()))
+				assert.NoError(t, err, "httptest server write failed")
+			},
+		),
+	)
+	defer server.Close()
+
+	smockerInstance := smockerclient.Instance{Url: server.URL, Retry: fastRetry}
+	err := smockerInstance.VerifyMocksInCurrentSession()
+
+	var verificationErr *smockerclient.VerificationError
+	assert.ErrorAs(t, err, &verificationErr)
+	assert.Equal(t, 1, serverCallCount)
+}
+
+func TestRetry_WhenRetryIsNotSet_SendsRequestOnce(t *testing.T) {
+	server, serverCallCount := newUnavailableServer(t, 5, http.StatusServiceUnavailable)
+	defer server.Close()
+
+	smockerInstance := smockerclient.Instance{Url: server.URL}
+	err := smockerInstance.ResetAllSessionsAndMocks()
+
+	assert.EqualError(t, err, "smockerclient unable to reset all the sessions and mocks. received status:503 and message:")
+	assert.Equal(t, 1, *serverCallCount)
+}
+
+func TestRetry_WhenContextIsDoneDuringBackoff_ReturnsContextError(t *testing.T) {
+	server, serverCallCount := newUnavailableServer(t, 5, http.StatusServiceUnavailable)
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	retry := smockerclient.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Hour}
+	smockerInstance := smockerclient.Instance{Url: server.URL, Retry: retry}
+	err := smockerInstance.ResetAllSessionsAndMocksContext(ctx)
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.ErrorContains(t, err, "last error: received status:503")
+	assert.Equal(t, 1, *serverCallCount)
+}
+
+// newUnavailableServer Responds with the status to the first unavailableCount requests and 200 OK after.
+func newUnavailableServer(t *testing.T, unavailableCount int, status int) (*httptest.Server, *int) {
+	serverCallCount := 0
+
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				serverCallCount++
+				if serverCallCount <= unavailableCount {
+					w.WriteHeader(status)
+				}
+			},
+		),
+	)
+
+	return server, &serverCallCount
+}
@@ -0,0 +1,223 @@
+package smockerclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ServerVersion The build information of the Smocker server.
+type ServerVersion struct {
+	AppName      string `json:"app_name"`
+	BuildVersion string `json:"build_version"`
+	BuildCommit  string `json:"build_commit"`
+	BuildDate    string `json:"build_date"`
+}
+
+// AtLeast Reports whether the server version is the same as or newer than the minimum version given, e.g. "0.18.0".
+// Versions that are not of the form major.minor.patch, such as development builds, are assumed to be the newest
+// version.
+func (v ServerVersion) AtLeast(minimum string) bool {
+	actual, ok := parseVersion(v.BuildVersion)
+	if !ok {
+		return true
+	}
+
+	required, ok := parseVersion(minimum)
+	if !ok {
+		return true
+	}
+
+	for index := range actual {
+		if actual[index] != required[index] {
+			return actual[index] > required[index]
+		}
+	}
+
+	return true
+}
+
+func parseVersion(version string) ([3]int, bool) {
+	var parsed [3]int
+
+	parts := strings.Split(strings.TrimPrefix(version, "v"), ".")
+	if len(parts) != len(parsed) {
+		return parsed, false
+	}
+
+	for index, part := range parts {
+		number, err := strconv.Atoi(part)
+		if err != nil {
+			return parsed, false
+		}
+		parsed[index] = number
+	}
+
+	return parsed, true
+}
+
+// Version Gets the build information of the Smocker server. The client does not check the version before using a
+// feature, use AtLeast to check the server supports it.
+func (i Instance) Version() (ServerVersion, error) {
+	return i.VersionContext(context.Background())
+}
+
+// VersionContext Is Version with a context to cancel the request and set its deadline.
+func (i Instance) VersionContext(ctx context.Context) (ServerVersion, error) {
+	resp, err := i.sendVersionRequest(ctx)
+	if err != nil {
+		return ServerVersion{}, fmt.Errorf("smockerclient unable to get the server version. %w", err)
+	}
+	defer resp.Body.Close()
+
+	err = handleNon200Response(resp)
+	if err != nil {
+		return ServerVersion{}, fmt.Errorf("smockerclient unable to get the server version. %w", err)
+	}
+
+	var version ServerVersion
+	err = json.NewDecoder(resp.Body).Decode(&version)
+	if err != nil {
+		return ServerVersion{}, fmt.Errorf("smockerclient unable to read json response to get the server version. %w", err)
+	}
+
+	return version, nil
+}
+
+func (i Instance) sendVersionRequest(ctx context.Context) (*http.Response, error) {
+	req, err := i.createVersionRequest(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create request. %w", err)
+	}
+
+	resp, err := i.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("unable to send request. %w", err)
+	}
+
+	return resp, nil
+}
+
+func (i Instance) createVersionRequest(ctx context.Context) (*http.Request, error) {
+	url, err := i.endpoint("/version")
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+type readyOptions struct {
+	mockServerUrl string
+}
+
+type ReadyOption func(options *readyOptions)
+
+// WithMockServerUrl Also waits for the Smocker mock server, the server the code under test calls, to respond. The url
+// given is used instead of the Instance's MockServerUrl.
+func WithMockServerUrl(url string) ReadyOption {
+	return func(options *readyOptions) {
+		options.mockServerUrl = url
+	}
+}
+
+const (
+	readyInitialBackoff = 50 * time.Millisecond
+	readyMaxBackoff     = time.Second
+)
+
+// WaitUntilReady Polls the Smocker admin server, and the mock server when the MockServerUrl is set, with an increasing
+// delay between attempts, until they respond or the context is done. Any http response other than 502, 503 or 504
+// counts as the server being ready, including the 666 status the mock server responds with when no mock matches. A
+// proxy in front of Smocker responding 502, 503 or 504 while Smocker is down is waited on.
+func (i Instance) WaitUntilReady(ctx context.Context, options ...ReadyOption) error {
+	var readyOpts readyOptions
+	for _, fn := range options {
+		fn(&readyOpts)
+	}
+
+	adminUrl, err := i.endpoint("/version")
+	if err != nil {
+		return fmt.Errorf("smockerclient unable to wait for the server to be ready. %w", err)
+	}
+
+	mockServerUrl := i.MockServerUrl
+	if readyOpts.mockServerUrl != "" {
+		mockServerUrl = readyOpts.mockServerUrl
+	}
+
+	err = i.waitForResponse(ctx, adminUrl, true)
+	if err != nil {
+		return fmt.Errorf("smockerclient server at %s did not become ready. %w", adminUrl, err)
+	}
+
+	if mockServerUrl != "" {
+		err = i.waitForResponse(ctx, mockServerUrl, false)
+		if err != nil {
+			return fmt.Errorf("smockerclient server at %s did not become ready. %w", mockServerUrl, err)
+		}
+	}
+
+	return nil
+}
+
+// waitForResponse Polls the url until it responds. The Instance's Auth is only added to requests to the admin server,
+// the mock server is called without credentials like the code under test calls it.
+func (i Instance) waitForResponse(ctx context.Context, url string, admin bool) error {
+	backoff := readyInitialBackoff
+	for {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return fmt.Errorf("unable to create request. %w", err)
+		}
+
+		if admin && i.Auth != nil {
+			i.Auth.Authenticate(req)
+		}
+
+		err = i.sendReadyRequest(req)
+		if err == nil {
+			return nil
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("%w. last error: %w", ctx.Err(), err)
+		case <-timer.C:
+		}
+
+		backoff = min(backoff*2, readyMaxBackoff)
+	}
+}
+
+func (i Instance) sendReadyRequest(req *http.Request) error {
+	resp, err := i.send(req)
+	if err != nil {
+		return fmt.Errorf("unable to send request. %w", err)
+	}
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if isGatewayError(resp.StatusCode) {
+		return fmt.Errorf("received status:%d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// isGatewayError Reports whether the status is one a proxy responds with when the server behind it is unavailable.
+func isGatewayError(status int) bool {
+	return status == http.StatusBadGateway || status == http.StatusServiceUnavailable || status == http.StatusGatewayTimeout
+}
@@ -0,0 +1,212 @@
+package smockerclient_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/churmd/smockerclient"
+)
+
+func TestVersion(t *testing.T) {
+	serverCallCount := 0
+
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				serverCallCount++
+
+				assert.Equal(t, http.MethodGet, r.Method)
+				assert.Equal(t, "/version", r.URL.Path)
+
+				resp := `{
+					"app_name": "smocker",
+					"build_version": "0.18.5",
+					"build_commit": "e5e7b2f",
+					"build_date": "2023-06-19T09:51:12Z"
+				}`
+				_, err := w.Write([]byte(resp))
+				assert.NoError(t, err, "httptest server write failed")
+			},
+		),
+	)
+	defer server.Close()
+
+	smockerInstance := smockerclient.Instance{Url: server.URL}
+	version, err := smockerInstance.Version()
+
+	expected := smockerclient.ServerVersion{
+		AppName:      "smocker",
+		BuildVersion: "0.18.5",
+		BuildCommit:  "e5e7b2f",
+		BuildDate:    "2023-06-19T09:51:12Z",
+	}
+	assert.NoError(t, err)
+	assert.Equal(t, expected, version)
+	assert.Equal(t, 1, serverCallCount)
+}
+
+func TestVersion_WhenServerDoesNotReturn200_ReturnsError(t *testing.T) {
+	server, serverCallCount := newBadResponseServer(t)
+	defer server.Close()
+
+	smockerInstance := smockerclient.Instance{Url: server.URL}
+	_, err := smockerInstance.Version()
+
+	assert.Equal(t, 1, *serverCallCount)
+	assert.EqualError(t, err, "smockerclient unable to get the server version. received status:400 and message:400 Bad Request")
+}
+
+func TestServerVersion_AtLeast(t *testing.T) {
+	tests := []struct {
+		version  string
+		minimum  string
+		expected bool
+	}{
+		{version: "0.18.5", minimum: "0.18.5", expected: true},
+		{version: "0.18.5", minimum: "0.17.0", expected: true},
+		{version: "0.18.5", minimum: "0.18.6", expected: false},
+		{version: "0.18.5", minimum: "1.0.0", expected: false},
+		{version: "v1.2.0", minimum: "0.18.5", expected: true},
+		{version: "dev", minimum: "0.18.5", expected: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.version+" at least "+test.minimum, func(t *testing.T) {
+			version := smockerclient.ServerVersion{BuildVersion: test.version}
+			assert.Equal(t, test.expected, version.AtLeast(test.minimum))
+		})
+	}
+}
+
+func TestWaitUntilReady_WhenServerIsNotReady_RetriesUntilItResponds(t *testing.T) {
+	server, serverCallCount := newDroppingServer(t, 2)
+	defer server.Close()
+
+	smockerInstance := smockerclient.Instance{Url: server.URL}
+	err := smockerInstance.WaitUntilReady(context.Background())
+
+	assert.NoError(t, err)
+	assert.EqualValues(t, 3, serverCallCount.Load())
+}
+
+func TestWaitUntilReady_WithMockServerUrl_WaitsForTheMockServer(t *testing.T) {
+	adminServer, adminCallCount := newDroppingServer(t, 0)
+	defer adminServer.Close()
+	mockServer, mockCallCount := newDroppingServer(t, 1)
+	defer mockServer.Close()
+
+	smockerInstance := smockerclient.Instance{Url: adminServer.URL}
+	err := smockerInstance.WaitUntilReady(context.Background(), smockerclient.WithMockServerUrl(mockServer.URL))
+
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, adminCallCount.Load())
+	assert.EqualValues(t, 2, mockCallCount.Load())
+}
+
+func TestWaitUntilReady_WithMockServerUrlField_WaitsForTheMockServer(t *testing.T) {
+	adminServer, adminCallCount := newDroppingServer(t, 0)
+	defer adminServer.Close()
+	mockServer, mockCallCount := newDroppingServer(t, 1)
+	defer mockServer.Close()
+
+	smockerInstance := smockerclient.Instance{Url: adminServer.URL, MockServerUrl: mockServer.URL}
+	err := smockerInstance.WaitUntilReady(context.Background())
+
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, adminCallCount.Load())
+	assert.EqualValues(t, 2, mockCallCount.Load())
+}
+
+func TestWaitUntilReady_WhenServerRespondsServiceUnavailable_RetriesUntilItIsReady(t *testing.T) {
+	server, serverCallCount := newUnavailableServer(t, 2, http.StatusServiceUnavailable)
+	defer server.Close()
+
+	smockerInstance := smockerclient.Instance{Url: server.URL}
+	err := smockerInstance.WaitUntilReady(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, *serverCallCount)
+}
+
+func TestWaitUntilReady_WhenServerAlwaysRespondsBadGateway_ReturnsError(t *testing.T) {
+	server, _ := newUnavailableServer(t, 1000, http.StatusBadGateway)
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	smockerInstance := smockerclient.Instance{Url: server.URL}
+	err := smockerInstance.WaitUntilReady(ctx)
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.ErrorContains(t, err, "last error: received status:502")
+}
+
+func TestWaitUntilReady_WhenMockServerRespondsNoMockFound_IsReady(t *testing.T) {
+	adminServer, _ := newDroppingServer(t, 0)
+	defer adminServer.Close()
+	mockServer, mockCallCount := newUnavailableServer(t, 1000, 666)
+	defer mockServer.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	smockerInstance := smockerclient.Instance{Url: adminServer.URL, MockServerUrl: mockServer.URL}
+	err := smockerInstance.WaitUntilReady(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, *mockCallCount)
+}
+
+func TestWaitUntilReady_WhenServerRespondsInternalServerError_IsReady(t *testing.T) {
+	server, serverCallCount := newUnavailableServer(t, 1000, http.StatusInternalServerError)
+	defer server.Close()
+
+	smockerInstance := smockerclient.Instance{Url: server.URL}
+	err := smockerInstance.WaitUntilReady(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, *serverCallCount)
+}
+
+func TestWaitUntilReady_WhenContextIsDone_ReturnsError(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	smockerInstance := smockerclient.Instance{Url: server.URL}
+	err := smockerInstance.WaitUntilReady(ctx)
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.ErrorContains(t, err, "smockerclient server at "+server.URL+"/version did not become ready.")
+}
+
+// newDroppingServer Creates a server that closes the connection without responding for the first dropCount requests.
+func newDroppingServer(t *testing.T, dropCount int32) (*httptest.Server, *atomic.Int32) {
+	var serverCallCount atomic.Int32
+
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				if serverCallCount.Add(1) > dropCount {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+
+				conn, _, err := w.(http.Hijacker).Hijack()
+				assert.NoError(t, err, "httptest server hijack failed")
+				assert.NoError(t, conn.Close(), "httptest server close failed")
+			},
+		),
+	)
+
+	return server, &serverCallCount
+}
@@ -0,0 +1,191 @@
+package smockerclient
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// SessionHandle A session on the Smocker server. Its methods only act on this session, unlike the methods of Instance
+// that act on the latest session. Create it with Instance.NewSession.
+//
+// When the Instance has LockSessions set, the handle holds exclusive use of the Smocker server until it is verified or
+// released. Starting the next session of a test from the handle with NewSession passes the lock on to the new handle.
+type SessionHandle struct {
+	instance Instance
+	id       string
+	name     string
+
+	mu    sync.Mutex
+	lease *sessionLease
+}
+
+// NewSession Starts a new session on the Smocker server with the given name and returns a handle bound to it.
+func (i Instance) NewSession(name string) (*SessionHandle, error) {
+	return i.NewSessionContext(context.Background(), name)
+}
+
+// NewSessionContext Is NewSession with a context to cancel the request and set its deadline.
+func (i Instance) NewSessionContext(ctx context.Context, name string) (*SessionHandle, error) {
+	if !i.LockSessions {
+		return i.newSession(ctx, name, nil)
+	}
+
+	lease, err := i.acquireSessionLock(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("smockerclient unable to create a new session named %s. %w", name, err)
+	}
+
+	session, err := i.newSession(ctx, name, lease)
+	if err != nil {
+		_ = lease.release()
+	}
+
+	return session, err
+}
+
+func (i Instance) newSession(ctx context.Context, name string, lease *sessionLease) (*SessionHandle, error) {
+	session, err := i.startSession(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SessionHandle{instance: i, id: session.ID, name: session.Name, lease: lease}, nil
+}
+
+// NewSession Starts the next session on the Smocker server with the given name. When this handle holds the session
+// lock it is passed on to the new handle without waiting, so a test can run several sessions in sequence, and this
+// handle no longer releases it.
+func (s *SessionHandle) NewSession(name string) (*SessionHandle, error) {
+	return s.NewSessionContext(context.Background(), name)
+}
+
+// NewSessionContext Is NewSession with a context to cancel the request and set its deadline.
+func (s *SessionHandle) NewSessionContext(ctx context.Context, name string) (*SessionHandle, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.lease == nil {
+		return s.instance.NewSessionContext(ctx, name)
+	}
+
+	session, err := s.instance.newSession(ctx, name, s.lease)
+	if err != nil {
+		return nil, err
+	}
+
+	s.lease = nil
+	return session, nil
+}
+
+// ID The id Smocker assigned to the session.
+func (s *SessionHandle) ID() string {
+	return s.id
+}
+
+// Name The name of the session.
+func (s *SessionHandle) Name() string {
+	return s.name
+}
+
+// AddMock Adds a new mock to the session. ReplacingExisting can not be used as it would remove the session.
+func (s *SessionHandle) AddMock(mock MockDefinition, options ...AddMockOption) error {
+	return s.AddMockContext(context.Background(), mock, options...)
+}
+
+// AddMockContext Is AddMock with a context to cancel the request and set its deadline.
+func (s *SessionHandle) AddMockContext(ctx context.Context, mock MockDefinition, options ...AddMockOption) error {
+	return s.instance.AddMockContext(ctx, mock, append(slices.Clip(options), InSession(s.id))...)
+}
+
+// AddMockReturningID Is AddMock that also returns the id Smocker assigned to the mock, see Instance.AddMockReturningID.
+func (s *SessionHandle) AddMockReturningID(mock MockDefinition, options ...AddMockOption) (string, error) {
+	return s.AddMockReturningIDContext(context.Background(), mock, options...)
+}
+
+// AddMockReturningIDContext Is AddMockReturningID with a context to cancel the requests and set their deadline.
+func (s *SessionHandle) AddMockReturningIDContext(ctx context.Context, mock MockDefinition, options ...AddMockOption) (string, error) {
+	return s.instance.AddMockReturningIDContext(ctx, mock, append(slices.Clip(options), InSession(s.id))...)
+}
+
+// AddMocks Adds all the mocks to the session in a single request and returns the ids Smocker assigned to them, in the
+// same order as the mocks given.
+func (s *SessionHandle) AddMocks(mocks ...MockDefinition) ([]string, error) {
+	return s.AddMocksContext(context.Background(), mocks...)
+}
+
+// AddMocksContext Is AddMocks with a context to cancel the request and set its deadline.
+func (s *SessionHandle) AddMocksContext(ctx context.Context, mocks ...MockDefinition) ([]string, error) {
+	return s.instance.addMocks(ctx, mocks, addMockOptions{sessionID: s.id})
+}
+
+// Mocks Gets the mocks registered in the session.
+func (s *SessionHandle) Mocks() ([]Mock, error) {
+	return s.MocksContext(context.Background())
+}
+
+// MocksContext Is Mocks with a context to cancel the request and set its deadline.
+func (s *SessionHandle) MocksContext(ctx context.Context) ([]Mock, error) {
+	return s.instance.GetMocksContext(ctx, s.id)
+}
+
+// History Gets the requests received by the Smocker mock server in the session, oldest first.
+func (s *SessionHandle) History() ([]HistoryEntry, error) {
+	return s.HistoryContext(context.Background())
+}
+
+// HistoryContext Is History with a context to cancel the request and set its deadline.
+func (s *SessionHandle) HistoryContext(ctx context.Context) ([]HistoryEntry, error) {
+	return s.instance.GetHistoryContext(ctx, s.id)
+}
+
+// Verify Verifies the mocks in the session have all been used and no other calls have been made, see
+// Instance.VerifySession. Releases the session lock when the handle holds it.
+func (s *SessionHandle) Verify() (VerificationResult, error) {
+	return s.VerifyContext(context.Background())
+}
+
+// VerifyContext Is Verify with a context to cancel the request and set its deadline.
+func (s *SessionHandle) VerifyContext(ctx context.Context) (VerificationResult, error) {
+	result, err := s.instance.VerifySessionContext(ctx, s.id)
+
+	releaseErr := s.Release()
+	if err == nil {
+		err = releaseErr
+	}
+
+	return result, err
+}
+
+// Release Gives up the handle's exclusive use of the Smocker server, taken when the Instance has LockSessions set. The
+// lock is released by verifying the session, this is for when a test ends without verifying, e.g. with t.Cleanup. Does
+// nothing when the handle does not hold the lock.
+func (s *SessionHandle) Release() error {
+	s.mu.Lock()
+	lease := s.lease
+	s.lease = nil
+	s.mu.Unlock()
+
+	if lease == nil {
+		return nil
+	}
+
+	return lease.release()
+}
+
+// Rename Changes the name of the session, e.g. to mark it as passed or failed so the Smocker UI is easier to browse.
+func (s *SessionHandle) Rename(name string) error {
+	return s.RenameContext(context.Background(), name)
+}
+
+// RenameContext Is Rename with a context to cancel the request and set its deadline.
+func (s *SessionHandle) RenameContext(ctx context.Context, name string) error {
+	session, err := s.instance.UpdateSessionContext(ctx, s.id, name)
+	if err != nil {
+		return err
+	}
+
+	s.name = session.Name
+	return nil
+}
@@ -0,0 +1,141 @@
+package smockerclient_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/churmd/smockerclient"
+	"github.com/churmd/smockerclient/mock"
+)
+
+func TestSessionHandle_MethodsAreBoundToTheSession(t *testing.T) {
+	sessionID := "Z9gF5kwSR"
+	var calls []string
+
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				calls = append(calls, r.Method+" "+r.URL.Path)
+
+				resp := ""
+				switch r.Method + " " + r.URL.Path {
+				case "POST /sessions":
+					assert.Equal(t, "my-session", r.URL.Query().Get("name"))
+					resp = `{"id": "Z9gF5kwSR", "name": "my-session", "date": "2023-04-26T14:41:30Z"}`
+				case "PUT /sessions":
+					body, err := io.ReadAll(r.Body)
+					assert.NoError(t, err)
+					assert.JSONEq(t, `{"id": "Z9gF5kwSR", "name": "PASSED my-session"}`, string(body))
+					resp = `{"id": "Z9gF5kwSR", "name": "PASSED my-session", "date": "2023-04-26T14:41:30Z"}`
+				case "POST /mocks":
+					assert.Equal(t, sessionID, r.URL.Query().Get("session"))
+				case "GET /mocks":
+					assert.Equal(t, sessionID, r.URL.Query().Get("session"))
+					resp = getAddedMocksBody()
+				case "GET /history":
+					assert.Equal(t, sessionID, r.URL.Query().Get("session"))
+					resp = getHistoryBody()
+				case "POST /sessions/verify":
+					assert.Equal(t, sessionID, r.URL.Query().Get("session"))
+					resp = `{"mocks": {"verified": true, "all_used": true}, "history": {"verified": true}}`
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL)
+				}
+
+				_, err := w.Write([]byte(resp))
+				assert.NoError(t, err, "httptest server write failed")
+			},
+		),
+	)
+	defer server.Close()
+
+	smockerInstance := smockerclient.Instance{Url: server.URL}
+	session, err := smockerInstance.NewSession("my-session")
+	assert.NoError(t, err)
+	assert.Equal(t, sessionID, session.ID())
+	assert.Equal(t, "my-session", session.Name())
+
+	definition := mock.NewRawJsonDefinition(`{"request": {"method": "GET", "path": "/example"}, "response": {"status": 200}}`)
+
+	id, err := session.AddMockReturningID(definition, smockerclient.InSession("another-session"))
+	assert.NoError(t, err)
+	assert.Equal(t, "Wq8ks4Rzq", id)
+
+	ids, err := session.AddMocks(definition, definition)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Pm3ks4Rza", "Wq8ks4Rzq"}, ids)
+
+	mocks, err := session.Mocks()
+	assert.NoError(t, err)
+	assert.Len(t, mocks, 3)
+
+	history, err := session.History()
+	assert.NoError(t, err)
+	assert.Len(t, history, 2)
+
+	result, err := session.Verify()
+	assert.NoError(t, err)
+	assert.True(t, result.Passed())
+
+	err = session.Rename("PASSED my-session")
+	assert.NoError(t, err)
+	assert.Equal(t, "PASSED my-session", session.Name())
+
+	expectedCalls := []string{
+		"POST /sessions",
+		"POST /mocks", "GET /mocks",
+		"POST /mocks", "GET /mocks",
+		"GET /mocks",
+		"GET /history",
+		"POST /sessions/verify",
+		"PUT /sessions",
+	}
+	assert.Equal(t, expectedCalls, calls)
+}
+
+func TestSessionHandle_AddMock_DoesNotChangeTheCallersOptions(t *testing.T) {
+	mocksServer := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/sessions" {
+					_, err := w.Write([]byte(`{"id": "Z9gF5kwSR", "name": "my-session"}`))
+					assert.NoError(t, err, "httptest server write failed")
+					return
+				}
+
+				if r.Method == http.MethodGet {
+					_, err := w.Write([]byte(getAddedMocksBody()))
+					assert.NoError(t, err, "httptest server write failed")
+				}
+			},
+		),
+	)
+	defer mocksServer.Close()
+
+	smockerInstance := smockerclient.Instance{Url: mocksServer.URL}
+	session, err := smockerInstance.NewSession("my-session")
+	assert.NoError(t, err)
+
+	backing := make([]smockerclient.AddMockOption, 2)
+	backing[0] = smockerclient.InSession("another-session")
+	options := backing[:1]
+
+	err = session.AddMock(mock.NewRawJsonDefinition(`{}`), options...)
+	assert.NoError(t, err)
+	assert.Nil(t, backing[1])
+}
+
+func TestNewSession_WhenServerDoesNotReturn200_ReturnsError(t *testing.T) {
+	server, _ := newBadResponseServer(t)
+	defer server.Close()
+
+	smockerInstance := smockerclient.Instance{Url: server.URL}
+	session, err := smockerInstance.NewSession("my-session")
+
+	assert.Nil(t, session)
+	assert.EqualError(t, err, "smockerclient unable to create a new session named my-session. received status:400 and message:400 Bad Request")
+}
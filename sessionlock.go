@@ -0,0 +1,143 @@
+package smockerclient
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const lockFilePollInterval = 50 * time.Millisecond
+
+// errLockHeld The lock file is locked by another process.
+var errLockHeld = errors.New("lock file is held by another process")
+
+// errStartSessionLocked StartSession was called on an Instance with LockSessions set.
+var errStartSessionLocked = errors.New("sessions are locked with LockSessions, use NewSession to start a session that holds the lock")
+
+// sessionLocks The session lock of each Smocker admin url, shared by every Instance in the process.
+var sessionLocks = struct {
+	sync.Mutex
+	byUrl map[string]*sessionLock
+}{byUrl: map[string]*sessionLock{}}
+
+// sessionLock Gives one caller at a time exclusive use of a Smocker server. The channel queues callers in the same
+// process and the lock file queues other processes, e.g. the test binaries of other packages.
+type sessionLock struct {
+	path  string
+	queue chan struct{}
+}
+
+// sessionLease Exclusive use of a Smocker server, held by the SessionHandle that acquired it.
+type sessionLease struct {
+	lock     *sessionLock
+	file     *os.File
+	mu       sync.Mutex
+	released bool
+}
+
+// sessionLock Gets the session lock of the Smocker server. Urls that only differ by a trailing slash or the case of the
+// host share a lock.
+func (i Instance) sessionLock() (*sessionLock, error) {
+	base, err := i.baseUrl()
+	if err != nil {
+		return nil, err
+	}
+	key := base.Scheme + "://" + strings.ToLower(base.Host) + strings.TrimSuffix(base.Path, "/")
+
+	sessionLocks.Lock()
+	defer sessionLocks.Unlock()
+
+	lock, ok := sessionLocks.byUrl[key]
+	if !ok {
+		sum := sha256.Sum256([]byte(key))
+		lock = &sessionLock{
+			path:  filepath.Join(os.TempDir(), "smockerclient-"+hex.EncodeToString(sum[:8])+".lock"),
+			queue: make(chan struct{}, 1),
+		}
+		sessionLocks.byUrl[key] = lock
+	}
+
+	return lock, nil
+}
+
+// acquireSessionLock Waits until the Smocker server is free and takes exclusive use of it, or the context is done.
+func (i Instance) acquireSessionLock(ctx context.Context) (*sessionLease, error) {
+	lock, err := i.sessionLock()
+	if err != nil {
+		return nil, fmt.Errorf("unable to lock the server. %w", err)
+	}
+
+	select {
+	case lock.queue <- struct{}{}:
+	case <-ctx.Done():
+		return nil, fmt.Errorf("unable to lock the server. %w", ctx.Err())
+	}
+
+	file, err := lockFile(ctx, lock.path)
+	if err != nil {
+		<-lock.queue
+		return nil, fmt.Errorf("unable to lock the server. %w", err)
+	}
+
+	return &sessionLease{lock: lock, file: file}, nil
+}
+
+// release Gives up exclusive use of the Smocker server. Does nothing when the lease has already been released.
+func (l *sessionLease) release() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.released {
+		return nil
+	}
+	l.released = true
+
+	err := unlockFile(l.file)
+	<-l.lock.queue
+	if err != nil {
+		return fmt.Errorf("smockerclient unable to release the session lock. %w", err)
+	}
+
+	return nil
+}
+
+// lockFile Opens and locks the file at path, waiting while another process holds the lock.
+func lockFile(ctx context.Context, path string) (*os.File, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open lock file. %w", err)
+	}
+
+	for {
+		err = tryLockFile(file)
+		if err == nil {
+			return file, nil
+		}
+
+		if !errors.Is(err, errLockHeld) {
+			file.Close()
+			return nil, fmt.Errorf("unable to lock file %s. %w", path, err)
+		}
+
+		timer := time.NewTimer(lockFilePollInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			file.Close()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func unlockFile(file *os.File) error {
+	err := unlockFileDescriptor(file)
+	return errors.Join(err, file.Close())
+}
@@ -0,0 +1,46 @@
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd
+
+package smockerclient_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/churmd/smockerclient"
+)
+
+func TestLockSessions_WhenAnotherProcessHoldsTheLockFile_Waits(t *testing.T) {
+	server := newSessionLockServer(t)
+	defer server.Close()
+
+	sum := sha256.Sum256([]byte(server.URL))
+	path := filepath.Join(os.TempDir(), "smockerclient-"+hex.EncodeToString(sum[:8])+".lock")
+
+	// A lock taken through a separate open file conflicts with the client's lock as if it was held by another process.
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0o644)
+	assert.NoError(t, err)
+	defer file.Close()
+	assert.NoError(t, syscall.Flock(int(file.Fd()), syscall.LOCK_EX))
+
+	smockerInstance := smockerclient.Instance{Url: server.URL, LockSessions: true}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 150*time.Millisecond)
+	defer cancel()
+
+	_, err = smockerInstance.NewSessionContext(ctx, "my-session")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	assert.NoError(t, syscall.Flock(int(file.Fd()), syscall.LOCK_UN))
+
+	session, err := smockerInstance.NewSession("my-session")
+	assert.NoError(t, err)
+	assert.NoError(t, session.Release())
+}
@@ -0,0 +1,17 @@
+//go:build !(darwin || dragonfly || freebsd || linux || netbsd || openbsd)
+
+package smockerclient
+
+import (
+	"os"
+)
+
+// tryLockFile Advisory file locks are not supported on this platform, so sessions are only locked within the process,
+// as documented on Instance.LockSessions.
+func tryLockFile(_ *os.File) error {
+	return nil
+}
+
+func unlockFileDescriptor(_ *os.File) error {
+	return nil
+}
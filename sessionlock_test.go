@@ -0,0 +1,188 @@
+package smockerclient_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/churmd/smockerclient"
+)
+
+func TestLockSessions_WhenSessionIsStarted_OtherCallersWaitUntilItIsVerified(t *testing.T) {
+	server := newSessionLockServer(t)
+	defer server.Close()
+
+	first := smockerclient.Instance{Url: server.URL, LockSessions: true}
+	second := smockerclient.Instance{Url: server.URL, LockSessions: true}
+
+	firstSession, err := first.NewSession("first")
+	assert.NoError(t, err)
+
+	err = assertServerIsLocked(second)
+	assert.EqualError(t, err, "smockerclient unable to create a new session named waiting. unable to lock the server. context deadline exceeded")
+
+	started := make(chan *smockerclient.SessionHandle)
+	go func() {
+		secondSession, err := second.NewSession("second")
+		assert.NoError(t, err)
+		started <- secondSession
+	}()
+
+	_, err = firstSession.Verify()
+	assert.NoError(t, err)
+
+	select {
+	case secondSession := <-started:
+		_, err = secondSession.Verify()
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("session not started after the first session was verified")
+	}
+}
+
+func TestLockSessions_OnlyTheHandleHoldingTheLockReleasesIt(t *testing.T) {
+	server := newSessionLockServer(t)
+	defer server.Close()
+
+	first := smockerclient.Instance{Url: server.URL, LockSessions: true}
+	second := smockerclient.Instance{Url: server.URL, LockSessions: true}
+
+	firstSession, err := first.NewSession("first")
+	assert.NoError(t, err)
+
+	_, err = second.VerifySession(firstSession.ID())
+	assert.NoError(t, err)
+	err = second.VerifyMocksInCurrentSession()
+	assert.NoError(t, err)
+	assert.ErrorIs(t, assertServerIsLocked(second), context.DeadlineExceeded)
+
+	assert.NoError(t, firstSession.Release())
+	assert.NoError(t, firstSession.Release())
+	assert.NoError(t, assertServerIsLocked(second))
+}
+
+func TestLockSessions_WhenSessionsAreStartedInSequence_PassesTheLockOn(t *testing.T) {
+	server := newSessionLockServer(t)
+	defer server.Close()
+
+	smockerInstance := smockerclient.Instance{Url: server.URL, LockSessions: true}
+	other := smockerclient.Instance{Url: server.URL, LockSessions: true}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	firstSession, err := smockerInstance.NewSessionContext(ctx, "first phase")
+	assert.NoError(t, err)
+
+	secondSession, err := firstSession.NewSessionContext(ctx, "second phase")
+	assert.NoError(t, err)
+
+	_, err = firstSession.Verify()
+	assert.NoError(t, err)
+	assert.ErrorIs(t, assertServerIsLocked(other), context.DeadlineExceeded)
+
+	_, err = secondSession.Verify()
+	assert.NoError(t, err)
+
+	thirdSession, err := smockerInstance.NewSessionContext(ctx, "after verify")
+	assert.NoError(t, err)
+	assert.NoError(t, thirdSession.Release())
+}
+
+func TestLockSessions_WhenUrlsOnlyDifferByATrailingSlash_ShareTheLock(t *testing.T) {
+	server := newSessionLockServer(t)
+	defer server.Close()
+
+	first := smockerclient.Instance{Url: server.URL + "/", LockSessions: true}
+	second := smockerclient.Instance{Url: server.URL, LockSessions: true}
+
+	firstSession, err := first.NewSession("first")
+	assert.NoError(t, err)
+	assert.ErrorIs(t, assertServerIsLocked(second), context.DeadlineExceeded)
+	assert.NoError(t, firstSession.Release())
+}
+
+func TestLockSessions_WhenNewSessionFails_ReleasesTheLock(t *testing.T) {
+	server, serverCallCount := newBadResponseServer(t)
+	defer server.Close()
+
+	smockerInstance := smockerclient.Instance{Url: server.URL, LockSessions: true}
+
+	for range 2 {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		_, err := smockerInstance.NewSessionContext(ctx, "my-session")
+		cancel()
+
+		assert.EqualError(t, err, "smockerclient unable to create a new session named my-session. received status:400 and message:400 Bad Request")
+	}
+	assert.Equal(t, 2, *serverCallCount)
+}
+
+func TestLockSessions_WhenNotSet_DoesNotLock(t *testing.T) {
+	server := newSessionLockServer(t)
+	defer server.Close()
+
+	smockerInstance := smockerclient.Instance{Url: server.URL}
+
+	_, err := smockerInstance.NewSession("first")
+	assert.NoError(t, err)
+	_, err = smockerInstance.NewSession("second")
+	assert.NoError(t, err)
+}
+
+func TestLockSessions_StartSession_ReturnsErrorWithoutSending(t *testing.T) {
+	serverCallCount := 0
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				serverCallCount++
+			},
+		),
+	)
+	defer server.Close()
+
+	smockerInstance := smockerclient.Instance{Url: server.URL, LockSessions: true}
+	err := smockerInstance.StartSession("my-session")
+
+	assert.EqualError(t, err, "smockerclient unable to create a new session named my-session. sessions are locked with LockSessions, use NewSession to start a session that holds the lock")
+	assert.Equal(t, 0, serverCallCount)
+}
+
+// assertServerIsLocked Tries to start a session, returning an error if the server stays locked for 100ms. The session
+// is released straight away when it starts.
+func assertServerIsLocked(instance smockerclient.Instance) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	session, err := instance.NewSessionContext(ctx, "waiting")
+	if err != nil {
+		return err
+	}
+
+	return session.Release()
+}
+
+func newSessionLockServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				resp := ""
+				switch r.URL.Path {
+				case "/sessions":
+					resp = `{"id": "Z9gF5kwSR", "name": "my-session"}`
+				case "/sessions/verify":
+					resp = `{"mocks": {"verified": true, "all_used": true}, "history": {"verified": true}}`
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL)
+				}
+
+				_, err := w.Write([]byte(resp))
+				assert.NoError(t, err, "httptest server write failed")
+			},
+		),
+	)
+}
@@ -0,0 +1,229 @@
+package smockerclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Session A session on the Smocker server along with the mocks and history recorded in it.
+type Session struct {
+	ID      string         `json:"id"`
+	Name    string         `json:"name"`
+	Date    time.Time      `json:"date"`
+	History []HistoryEntry `json:"history"`
+	Mocks   []Mock         `json:"mocks"`
+}
+
+// ListSessions Gets all the sessions on the Smocker server, oldest first.
+func (i Instance) ListSessions() ([]Session, error) {
+	return i.ListSessionsContext(context.Background())
+}
+
+// ListSessionsContext Is ListSessions with a context to cancel the request and set its deadline.
+func (i Instance) ListSessionsContext(ctx context.Context) ([]Session, error) {
+	resp, err := i.sendListSessionsRequest(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("smockerclient unable to list the sessions. %w", err)
+	}
+	defer resp.Body.Close()
+
+	err = handleNon200Response(resp)
+	if err != nil {
+		return nil, fmt.Errorf("smockerclient unable to list the sessions. %w", err)
+	}
+
+	var sessions []Session
+	err = json.NewDecoder(resp.Body).Decode(&sessions)
+	if err != nil {
+		return nil, fmt.Errorf("smockerclient unable to read json response to list the sessions. %w", err)
+	}
+
+	return sessions, nil
+}
+
+func (i Instance) sendListSessionsRequest(ctx context.Context) (*http.Response, error) {
+	req, err := i.createListSessionsRequest(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create request. %w", err)
+	}
+
+	resp, err := i.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("unable to send request. %w", err)
+	}
+
+	return resp, nil
+}
+
+func (i Instance) createListSessionsRequest(ctx context.Context) (*http.Request, error) {
+	url, err := i.endpoint("/sessions")
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// UpdateSession Renames the session with the given id and returns the updated session.
+func (i Instance) UpdateSession(id, name string) (Session, error) {
+	return i.UpdateSessionContext(context.Background(), id, name)
+}
+
+// UpdateSessionContext Is UpdateSession with a context to cancel the request and set its deadline.
+func (i Instance) UpdateSessionContext(ctx context.Context, id, name string) (Session, error) {
+	resp, err := i.sendUpdateSessionRequest(ctx, id, name)
+	if err != nil {
+		return Session{}, fmt.Errorf("smockerclient unable to rename session %s to %s. %w", id, name, err)
+	}
+	defer resp.Body.Close()
+
+	err = handleNon200Response(resp)
+	if err != nil {
+		return Session{}, fmt.Errorf("smockerclient unable to rename session %s to %s. %w", id, name, err)
+	}
+
+	var session Session
+	err = json.NewDecoder(resp.Body).Decode(&session)
+	if err != nil {
+		return Session{}, fmt.Errorf("smockerclient unable to read json response to rename session %s to %s. %w", id, name, err)
+	}
+
+	return session, nil
+}
+
+func (i Instance) sendUpdateSessionRequest(ctx context.Context, id, name string) (*http.Response, error) {
+	req, err := i.createUpdateSessionRequest(ctx, id, name)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create request. %w", err)
+	}
+
+	resp, err := i.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("unable to send request. %w", err)
+	}
+
+	return resp, nil
+}
+
+func (i Instance) createUpdateSessionRequest(ctx context.Context, id, name string) (*http.Request, error) {
+	update := struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	}{
+		ID:   id,
+		Name: name,
+	}
+
+	body := &bytes.Buffer{}
+	err := json.NewEncoder(body).Encode(update)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create request body bytes from session. %w", err)
+	}
+
+	url, err := i.endpoint("/sessions")
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, url, body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", "application/json")
+	return req, nil
+}
+
+// ExportSessions Writes all the sessions on the Smocker server to w, in the same json format Smocker uses for its own
+// session dumps. The output can be restored with ImportSessions.
+func (i Instance) ExportSessions(w io.Writer) error {
+	return i.ExportSessionsContext(context.Background(), w)
+}
+
+// ExportSessionsContext Is ExportSessions with a context to cancel the request and set its deadline.
+func (i Instance) ExportSessionsContext(ctx context.Context, w io.Writer) error {
+	resp, err := i.sendListSessionsRequest(ctx)
+	if err != nil {
+		return fmt.Errorf("smockerclient unable to export the sessions. %w", err)
+	}
+	defer resp.Body.Close()
+
+	err = handleNon200Response(resp)
+	if err != nil {
+		return fmt.Errorf("smockerclient unable to export the sessions. %w", err)
+	}
+
+	_, err = io.Copy(w, resp.Body)
+	if err != nil {
+		return fmt.Errorf("smockerclient unable to write the exported sessions. %w", err)
+	}
+
+	return nil
+}
+
+// ImportSessions Replaces all the sessions and mocks on the Smocker server with the sessions read from r, restoring the
+// server to the state they were exported in. r must contain json in the format written by ExportSessions or Smocker's
+// own session dumps.
+func (i Instance) ImportSessions(r io.Reader) error {
+	return i.ImportSessionsContext(context.Background(), r)
+}
+
+// ImportSessionsContext Is ImportSessions with a context to cancel the request and set its deadline.
+func (i Instance) ImportSessionsContext(ctx context.Context, r io.Reader) error {
+	resp, err := i.sendImportSessionsRequest(ctx, r)
+	if err != nil {
+		return fmt.Errorf("smockerclient unable to import the sessions. %w", err)
+	}
+	defer resp.Body.Close()
+
+	err = handleNon200Response(resp)
+	if err != nil {
+		return fmt.Errorf("smockerclient unable to import the sessions. %w", err)
+	}
+
+	return nil
+}
+
+func (i Instance) sendImportSessionsRequest(ctx context.Context, r io.Reader) (*http.Response, error) {
+	req, err := i.createImportSessionsRequest(ctx, r)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create request. %w", err)
+	}
+
+	resp, err := i.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("unable to send request. %w", err)
+	}
+
+	return resp, nil
+}
+
+func (i Instance) createImportSessionsRequest(ctx context.Context, r io.Reader) (*http.Request, error) {
+	sessions, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the sessions to import. %w", err)
+	}
+
+	url, err := i.endpoint("/sessions/import")
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(sessions))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", "application/json")
+	return req, nil
+}
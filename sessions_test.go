@@ -0,0 +1,319 @@
+package smockerclient_test
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/churmd/smockerclient"
+	"github.com/churmd/smockerclient/mock"
+)
+
+func TestListSessions(t *testing.T) {
+	serverCallCount := 0
+
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				serverCallCount++
+
+				assert.Equal(t, http.MethodGet, r.Method)
+				assert.Equal(t, "/sessions", r.URL.Path)
+
+				_, err := w.Write([]byte(getSessionsBody()))
+				assert.NoError(t, err, "httptest server write failed")
+			},
+		),
+	)
+	defer server.Close()
+
+	smockerInstance := smockerclient.Instance{Url: server.URL}
+	sessions, err := smockerInstance.ListSessions()
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, serverCallCount)
+
+	expectedRequest := mock.NewRequestBuilder(http.MethodGet, "/example").
+		AddHeader("Accept", "application/json").
+		Build()
+	expectedResponse := mock.NewResponseBuilder(http.StatusOK).
+		AddHeader("Content-Type", "application/json").
+		AddBody(`{"status": "OK"}`).
+		Build()
+	expected := []smockerclient.Session{
+		{
+			ID:   "Z9gF5kwSR",
+			Name: "my-session",
+			Date: time.Date(2023, 4, 26, 14, 41, 30, 0, time.UTC),
+			History: []smockerclient.HistoryEntry{
+				{
+					Context: smockerclient.HistoryContext{MockID: "bqeh8ks4R", MockType: "static"},
+					Request: smockerclient.HistoryRequest{
+						Method:      http.MethodGet,
+						Path:        "/example",
+						QueryParams: map[string][]string{"limit": {"10"}},
+						Headers:     map[string][]string{"Accept": {"application/json"}},
+						Body:        []byte(`""`),
+						Origin:      "172.17.0.1",
+						Date:        time.Date(2023, 4, 26, 14, 42, 53, 0, time.UTC),
+					},
+					Response: smockerclient.HistoryResponse{
+						Status:  http.StatusOK,
+						Headers: map[string][]string{"Content-Type": {"application/json"}},
+						Body:    []byte(`{"status": "OK"}`),
+						Date:    time.Date(2023, 4, 26, 14, 42, 54, 0, time.UTC),
+					},
+				},
+			},
+			Mocks: []smockerclient.Mock{
+				{
+					Definition: mock.NewDefinition(expectedRequest, expectedResponse, mock.WithCallLimit(1)),
+					State: smockerclient.MockState{
+						ID:           "bqeh8ks4R",
+						TimesCount:   1,
+						Locked:       false,
+						CreationDate: time.Date(2023, 4, 26, 14, 41, 43, 0, time.UTC),
+					},
+				},
+			},
+		},
+	}
+	assert.Equal(t, expected, sessions)
+}
+
+func TestListSessions_WhenServerDoesNotReturn200_ReturnsError(t *testing.T) {
+	server, serverCallCount := newBadResponseServer(t)
+	defer server.Close()
+
+	smockerInstance := smockerclient.Instance{Url: server.URL}
+	sessions, err := smockerInstance.ListSessions()
+
+	assert.Equal(t, 1, *serverCallCount)
+	assert.Nil(t, sessions)
+	assert.EqualError(t, err, "smockerclient unable to list the sessions. received status:400 and message:400 Bad Request")
+}
+
+func TestListSessions_WhenResponseIsNotJson_ReturnsError(t *testing.T) {
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				_, err := w.Write([]byte("not json"))
+				assert.NoError(t, err, "httptest server write failed")
+			},
+		),
+	)
+	defer server.Close()
+
+	smockerInstance := smockerclient.Instance{Url: server.URL}
+	_, err := smockerInstance.ListSessions()
+
+	assert.ErrorContains(t, err, "smockerclient unable to read json response to list the sessions.")
+}
+
+func getSessionsBody() string {
+	return `[
+    {
+        "id": "Z9gF5kwSR",
+        "name": "my-session",
+        "date": "2023-04-26T14:41:30Z",
+        "history": [
+            {
+                "context": {
+                    "mock_id": "bqeh8ks4R",
+                    "mock_type": "static"
+                },
+                "request": {
+                    "path": "/example",
+                    "method": "GET",
+                    "origin": "172.17.0.1",
+                    "body": "",
+                    "query_params": {
+                        "limit": ["10"]
+                    },
+                    "headers": {
+                        "Accept": ["application/json"]
+                    },
+                    "date": "2023-04-26T14:42:53Z"
+                },
+                "response": {
+                    "status": 200,
+                    "body": {"status": "OK"},
+                    "headers": {
+                        "Content-Type": ["application/json"]
+                    },
+                    "date": "2023-04-26T14:42:54Z"
+                }
+            }
+        ],
+        "mocks": [
+            {
+                "request": {
+                    "path": {"matcher": "ShouldEqual", "value": "/example"},
+                    "method": {"matcher": "ShouldEqual", "value": "GET"},
+                    "headers": {
+                        "Accept": [{"matcher": "ShouldEqual", "value": "application/json"}]
+                    }
+                },
+                "response": {
+                    "status": 200,
+                    "headers": {
+                        "Content-Type": ["application/json"]
+                    },
+                    "body": "{\"status\": \"OK\"}",
+                    "delay": {}
+                },
+                "context": {
+                    "times": 1
+                },
+                "state": {
+                    "id": "bqeh8ks4R",
+                    "times_count": 1,
+                    "locked": false,
+                    "creation_date": "2023-04-26T14:41:43Z"
+                }
+            }
+        ]
+    }
+]`
+}
+
+func TestExportSessions(t *testing.T) {
+	serverCallCount := 0
+	sessionsJson := getSessionsBody()
+
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				serverCallCount++
+
+				assert.Equal(t, http.MethodGet, r.Method)
+				assert.Equal(t, "/sessions", r.URL.Path)
+
+				_, err := w.Write([]byte(sessionsJson))
+				assert.NoError(t, err, "httptest server write failed")
+			},
+		),
+	)
+	defer server.Close()
+
+	var export bytes.Buffer
+	smockerInstance := smockerclient.Instance{Url: server.URL}
+	err := smockerInstance.ExportSessions(&export)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, serverCallCount)
+	assert.Equal(t, sessionsJson, export.String())
+}
+
+func TestExportSessions_WhenServerDoesNotReturn200_ReturnsError(t *testing.T) {
+	server, serverCallCount := newBadResponseServer(t)
+	defer server.Close()
+
+	var export bytes.Buffer
+	smockerInstance := smockerclient.Instance{Url: server.URL}
+	err := smockerInstance.ExportSessions(&export)
+
+	assert.Equal(t, 1, *serverCallCount)
+	assert.Empty(t, export.String())
+	assert.EqualError(t, err, "smockerclient unable to export the sessions. received status:400 and message:400 Bad Request")
+}
+
+func TestImportSessions(t *testing.T) {
+	serverCallCount := 0
+	sessionsJson := getSessionsBody()
+
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				serverCallCount++
+
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Equal(t, "/sessions/import", r.URL.Path)
+				assert.Equal(t, jsonContentType, r.Header.Get("Content-Type"))
+
+				body, err := io.ReadAll(r.Body)
+				assert.NoError(t, err)
+				assert.Equal(t, sessionsJson, string(body))
+
+				_, err = w.Write([]byte(sessionsJson))
+				assert.NoError(t, err, "httptest server write failed")
+			},
+		),
+	)
+	defer server.Close()
+
+	smockerInstance := smockerclient.Instance{Url: server.URL}
+	err := smockerInstance.ImportSessions(strings.NewReader(sessionsJson))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, serverCallCount)
+}
+
+func TestImportSessions_WhenServerDoesNotReturn200_ReturnsError(t *testing.T) {
+	server, serverCallCount := newBadResponseServer(t)
+	defer server.Close()
+
+	smockerInstance := smockerclient.Instance{Url: server.URL}
+	err := smockerInstance.ImportSessions(strings.NewReader("[]"))
+
+	assert.Equal(t, 1, *serverCallCount)
+	assert.EqualError(t, err, "smockerclient unable to import the sessions. received status:400 and message:400 Bad Request")
+}
+
+func TestUpdateSession(t *testing.T) {
+	serverCallCount := 0
+
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				serverCallCount++
+
+				assert.Equal(t, http.MethodPut, r.Method)
+				assert.Equal(t, "/sessions", r.URL.Path)
+				assert.Equal(t, jsonContentType, r.Header.Get("Content-Type"))
+
+				body, err := io.ReadAll(r.Body)
+				assert.NoError(t, err)
+				assert.JSONEq(t, `{"id": "Z9gF5kwSR", "name": "PASSED my-session"}`, string(body))
+
+				resp := `{
+					"id": "Z9gF5kwSR",
+					"name": "PASSED my-session",
+					"date": "2023-04-26T14:41:30Z"
+				}`
+				_, err = w.Write([]byte(resp))
+				assert.NoError(t, err, "httptest server write failed")
+			},
+		),
+	)
+	defer server.Close()
+
+	smockerInstance := smockerclient.Instance{Url: server.URL}
+	session, err := smockerInstance.UpdateSession("Z9gF5kwSR", "PASSED my-session")
+
+	expected := smockerclient.Session{
+		ID:   "Z9gF5kwSR",
+		Name: "PASSED my-session",
+		Date: time.Date(2023, 4, 26, 14, 41, 30, 0, time.UTC),
+	}
+	assert.NoError(t, err)
+	assert.Equal(t, expected, session)
+	assert.Equal(t, 1, serverCallCount)
+}
+
+func TestUpdateSession_WhenServerDoesNotReturn200_ReturnsError(t *testing.T) {
+	server, serverCallCount := newBadResponseServer(t)
+	defer server.Close()
+
+	smockerInstance := smockerclient.Instance{Url: server.URL}
+	_, err := smockerInstance.UpdateSession("Z9gF5kwSR", "PASSED my-session")
+
+	assert.Equal(t, 1, *serverCallCount)
+	assert.EqualError(t, err, "smockerclient unable to rename session Z9gF5kwSR to PASSED my-session. received status:400 and message:400 Bad Request")
+}
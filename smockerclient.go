@@ -2,22 +2,41 @@ package smockerclient
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
+	"net/url"
+
+	"github.com/churmd/smockerclient/mock"
 )
 
 // MockDefinition Allows multiple styles of mock creation to be used and custom extension.
 // ToMockDefinitionJson must return json conforming to the smocker mock definition
 // https://smocker.dev/technical-documentation/mock-definition.html as bytes.
-type MockDefinition interface {
-	ToMockDefinitionJson() ([]byte, error)
-}
+type MockDefinition = mock.JsonDefinition
 
 type Instance struct {
-	Url        string
-	HttpClient *http.Client
+	Url string
+	// MockServerUrl The url of the Smocker mock server, the server the code under test calls. Only used to wait for the
+	// mock server to be ready, it is kept on the Instance so tests can point the code under test at it.
+	MockServerUrl string
+	HttpClient    *http.Client
+	// Retry How requests are retried when the Smocker server is briefly unavailable. The zero value does not retry.
+	Retry RetryPolicy
+	// Logger Logs every request sent to the Smocker admin server when set.
+	Logger *slog.Logger
+	// Auth Adds credentials to every request sent to the Smocker admin server when set.
+	Auth AdminAuth
+	// LockSessions Gives each session started with NewSession exclusive use of the Smocker server until the session is
+	// verified or released. Other callers of NewSession using the same Url wait until it is free. Callers in other
+	// processes, e.g. the tests of other packages, also wait on linux, macOS and the BSDs, other platforms only lock
+	// within the process. StartSession returns an error when it is set, as only the SessionHandle returned by NewSession
+	// can hold the lock.
+	LockSessions bool
 }
 
 // Deprecated: Use zero value struct initialisation instead, e.g. Instance{}
@@ -49,6 +68,41 @@ func (i Instance) url() string {
 	return i.Url
 }
 
+// Validate Checks the Url is an absolute http or https url. Every request to the Smocker server validates the Url, this
+// allows a malformed Url to be reported before any request is made.
+func (i Instance) Validate() error {
+	_, err := i.baseUrl()
+	return err
+}
+
+func (i Instance) baseUrl() (*url.URL, error) {
+	base, err := url.Parse(i.url())
+	if err != nil {
+		return nil, markInvalidUrl(fmt.Errorf("smockerclient url %q is invalid. %w", i.url(), err))
+	}
+
+	if base.Scheme != "http" && base.Scheme != "https" {
+		return nil, markInvalidUrl(fmt.Errorf("smockerclient url %q is invalid. it must use the http or https scheme", i.url()))
+	}
+
+	if base.Host == "" {
+		return nil, markInvalidUrl(fmt.Errorf("smockerclient url %q is invalid. it must include a host", i.url()))
+	}
+
+	return base, nil
+}
+
+// endpoint Joins the path of a Smocker admin endpoint onto the Url, keeping any path prefix the Url has, e.g.
+// http://example.com/smocker-admin and /sessions become http://example.com/smocker-admin/sessions.
+func (i Instance) endpoint(path string) (string, error) {
+	base, err := i.baseUrl()
+	if err != nil {
+		return "", err
+	}
+
+	return base.JoinPath(path).String(), nil
+}
+
 // DefaultHttpClient The default http client to use to send requests to the smocker server
 var DefaultHttpClient = http.DefaultClient
 
@@ -61,28 +115,60 @@ func (i Instance) httpClient() *http.Client {
 }
 
 // StartSession Starts a new session on the Smocker server with the given name. New mocks will be added to the latest
-// session started.
+// session started. Returns an error when LockSessions is set, as only the SessionHandle returned by NewSession can hold
+// the session lock.
 func (i Instance) StartSession(name string) error {
-	resp, err := i.sendStartSessionRequest(name)
+	return i.StartSessionContext(context.Background(), name)
+}
+
+// StartSessionContext Is StartSession with a context to cancel the request and set its deadline.
+func (i Instance) StartSessionContext(ctx context.Context, name string) error {
+	_, err := i.StartSessionReturningContext(ctx, name)
+	return err
+}
+
+// StartSessionReturning Is StartSession that also returns the id and name Smocker gave the new session.
+func (i Instance) StartSessionReturning(name string) (Session, error) {
+	return i.StartSessionReturningContext(context.Background(), name)
+}
+
+// StartSessionReturningContext Is StartSessionReturning with a context to cancel the request and set its deadline.
+func (i Instance) StartSessionReturningContext(ctx context.Context, name string) (Session, error) {
+	if i.LockSessions {
+		return Session{}, fmt.Errorf("smockerclient unable to create a new session named %s. %w", name, errStartSessionLocked)
+	}
+
+	return i.startSession(ctx, name)
+}
+
+func (i Instance) startSession(ctx context.Context, name string) (Session, error) {
+	resp, err := i.sendStartSessionRequest(ctx, name)
 	if err != nil {
-		return fmt.Errorf("smockerclient unable to create a new session named %s. %w", name, err)
+		return Session{}, fmt.Errorf("smockerclient unable to create a new session named %s. %w", name, err)
 	}
+	defer resp.Body.Close()
 
 	err = handleNon200Response(resp)
 	if err != nil {
-		return fmt.Errorf("smockerclient unable to create a new session named %s. %w", name, err)
+		return Session{}, fmt.Errorf("smockerclient unable to create a new session named %s. %w", name, err)
 	}
 
-	return nil
+	var session Session
+	err = json.NewDecoder(resp.Body).Decode(&session)
+	if err != nil {
+		return Session{}, fmt.Errorf("smockerclient unable to read json response to create a new session named %s. %w", name, err)
+	}
+
+	return session, nil
 }
 
-func (i Instance) sendStartSessionRequest(name string) (*http.Response, error) {
-	req, err := i.createSessionRequest(name)
+func (i Instance) sendStartSessionRequest(ctx context.Context, name string) (*http.Response, error) {
+	req, err := i.createSessionRequest(ctx, name)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create request. %w", err)
 	}
 
-	resp, err := i.httpClient().Do(req)
+	resp, err := i.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("unable to send request. %w", err)
 	}
@@ -90,9 +176,13 @@ func (i Instance) sendStartSessionRequest(name string) (*http.Response, error) {
 	return resp, nil
 }
 
-func (i Instance) createSessionRequest(name string) (*http.Request, error) {
-	url := i.url() + "/sessions"
-	req, err := http.NewRequest(http.MethodPost, url, nil)
+func (i Instance) createSessionRequest(ctx context.Context, name string) (*http.Request, error) {
+	url, err := i.endpoint("/sessions")
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -104,12 +194,90 @@ func (i Instance) createSessionRequest(name string) (*http.Request, error) {
 	return req, nil
 }
 
-// AddMock Adds a new mock to the latest session on the Smocker server.
-func (i Instance) AddMock(mock MockDefinition) error {
-	resp, err := i.sendAddMockRequest(mock)
+type addMockOptions struct {
+	sessionID string
+	reset     bool
+}
+
+type AddMockOption func(options *addMockOptions)
+
+// InSession Adds the mock to the session with the given id instead of the latest session.
+func InSession(sessionID string) AddMockOption {
+	return func(options *addMockOptions) {
+		options.sessionID = sessionID
+	}
+}
+
+// ReplacingExisting Resets the Smocker server before the new mock is added, the same as ResetAllSessionsAndMocks. Every
+// session and every unlocked mock is removed, not only the mocks in the latest session, and the new mock is added to a
+// new session. It can not be used with InSession as the session no longer exists once the server is reset.
+func ReplacingExisting() AddMockOption {
+	return func(options *addMockOptions) {
+		options.reset = true
+	}
+}
+
+func newAddMockOptions(options []AddMockOption) addMockOptions {
+	var addOpts addMockOptions
+	for _, fn := range options {
+		fn(&addOpts)
+	}
+
+	return addOpts
+}
+
+func (o addMockOptions) validate() error {
+	if o.reset && o.sessionID != "" {
+		return errors.New("ReplacingExisting can not be used with InSession as it removes every session")
+	}
+
+	return nil
+}
+
+// AddMock Adds a new mock to the latest session on the Smocker server. Options can be given to add the mock to another
+// session or to reset the server first.
+func (i Instance) AddMock(mock MockDefinition, options ...AddMockOption) error {
+	return i.AddMockContext(context.Background(), mock, options...)
+}
+
+// AddMockContext Is AddMock with a context to cancel the request and set its deadline.
+func (i Instance) AddMockContext(ctx context.Context, mock MockDefinition, options ...AddMockOption) error {
+	return i.addMock(ctx, mock, newAddMockOptions(options))
+}
+
+// AddMockReturningID Is AddMock that also returns the id Smocker assigned to the mock, e.g. to lock it with LockMocks.
+//
+// Smocker does not return the ids of new mocks, so the id is found with a second request getting the newest mock in the
+// session after it has been added. Mocks added to the same session at the same time, e.g. by another goroutine, can be
+// returned in its place. When the mock was added but its id could not be found the error is ErrMockIDUnknown, the mock
+// should not be added again.
+func (i Instance) AddMockReturningID(mock MockDefinition, options ...AddMockOption) (string, error) {
+	return i.AddMockReturningIDContext(context.Background(), mock, options...)
+}
+
+// AddMockReturningIDContext Is AddMockReturningID with a context to cancel the requests and set their deadline.
+func (i Instance) AddMockReturningIDContext(ctx context.Context, mock MockDefinition, options ...AddMockOption) (string, error) {
+	addOpts := newAddMockOptions(options)
+
+	err := i.addMock(ctx, mock, addOpts)
+	if err != nil {
+		return "", err
+	}
+
+	ids, err := i.newestMockIDs(ctx, addOpts.sessionID, 1)
+	if err != nil {
+		return "", markMockIDUnknown(fmt.Errorf("smockerclient added mock but was unable to find its id. %w", err))
+	}
+
+	return ids[0], nil
+}
+
+func (i Instance) addMock(ctx context.Context, mock MockDefinition, options addMockOptions) error {
+	resp, err := i.sendAddMocksRequest(ctx, []MockDefinition{mock}, options)
 	if err != nil {
 		return fmt.Errorf("smockerclient unable to add a new mock. %w", err)
 	}
+	defer resp.Body.Close()
 
 	err = handleNon200Response(resp)
 	if err != nil {
@@ -119,13 +287,54 @@ func (i Instance) AddMock(mock MockDefinition) error {
 	return nil
 }
 
-func (i Instance) sendAddMockRequest(mock MockDefinition) (*http.Response, error) {
-	req, err := i.createAddMockRequest(mock)
+// AddMocks Adds all the mocks to the latest session on the Smocker server in a single request and returns the ids
+// Smocker assigned to them, in the same order as the mocks given. The ids are found the same way as AddMockReturningID,
+// with the same limits when mocks are added to the session at the same time.
+func (i Instance) AddMocks(mocks ...MockDefinition) ([]string, error) {
+	return i.AddMocksContext(context.Background(), mocks...)
+}
+
+// AddMocksContext Is AddMocks with a context to cancel the request and set its deadline.
+func (i Instance) AddMocksContext(ctx context.Context, mocks ...MockDefinition) ([]string, error) {
+	return i.addMocks(ctx, mocks, addMockOptions{})
+}
+
+func (i Instance) addMocks(ctx context.Context, mocks []MockDefinition, options addMockOptions) ([]string, error) {
+	if len(mocks) == 0 {
+		return nil, nil
+	}
+
+	resp, err := i.sendAddMocksRequest(ctx, mocks, options)
+	if err != nil {
+		return nil, fmt.Errorf("smockerclient unable to add %d new mocks. %w", len(mocks), err)
+	}
+	defer resp.Body.Close()
+
+	err = handleNon200Response(resp)
+	if err != nil {
+		return nil, fmt.Errorf("smockerclient unable to add %d new mocks. %w", len(mocks), err)
+	}
+
+	ids, err := i.newestMockIDs(ctx, options.sessionID, len(mocks))
+	if err != nil {
+		return nil, markMockIDUnknown(fmt.Errorf("smockerclient added %d mocks but was unable to find their ids. %w", len(mocks), err))
+	}
+
+	return ids, nil
+}
+
+func (i Instance) sendAddMocksRequest(ctx context.Context, mocks []MockDefinition, options addMockOptions) (*http.Response, error) {
+	err := options.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := i.createAddMocksRequest(ctx, mocks, options)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create request. %w", err)
 	}
 
-	resp, err := i.httpClient().Do(req)
+	resp, err := i.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("unable to send request. %w", err)
 	}
@@ -133,34 +342,53 @@ func (i Instance) sendAddMockRequest(mock MockDefinition) (*http.Response, error
 	return resp, nil
 }
 
-func (i Instance) createAddMockRequest(mock MockDefinition) (*http.Request, error) {
-	body, err := createAddMockRequestBody(mock)
+func (i Instance) createAddMocksRequest(ctx context.Context, mocks []MockDefinition, options addMockOptions) (*http.Request, error) {
+	body, err := createAddMocksRequestBody(mocks)
+	if err != nil {
+		return nil, err
+	}
+
+	url, err := i.endpoint("/mocks")
 	if err != nil {
 		return nil, err
 	}
 
-	url := i.url() + "/mocks"
-	req, err := http.NewRequest(http.MethodPost, url, body)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, body)
 	if err != nil {
 		return nil, err
 	}
 
+	addSessionQueryParam(req, options.sessionID)
+	if options.reset {
+		query := req.URL.Query()
+		query.Add("reset", "true")
+		req.URL.RawQuery = query.Encode()
+	}
+
 	req.Header.Add("Content-Type", "application/json")
 	return req, nil
 }
 
-func createAddMockRequestBody(mock MockDefinition) (*bytes.Buffer, error) {
-	mockJson, err := mock.ToMockDefinitionJson()
-	if err != nil {
-		return nil, fmt.Errorf("unable to convert mock to json when running ToMockDefinitionJson. %w", err)
+func createAddMocksRequestBody(mocks []MockDefinition) (*bytes.Buffer, error) {
+	// Smocker API always expects a list of mocks to be sent
+	mocksJson := make([]json.RawMessage, 0, len(mocks))
+	for index, mock := range mocks {
+		mockJson, err := mock.ToMockDefinitionJson()
+		if err != nil {
+			return nil, markInvalidDefinition(fmt.Errorf("mock definition %d is invalid. unable to convert mock to json when running ToMockDefinitionJson. %w", index, err))
+		}
+
+		if !json.Valid(mockJson) {
+			return nil, markInvalidDefinition(fmt.Errorf("mock definition %d is invalid. ToMockDefinitionJson returned invalid json: %s", index, mockJson))
+		}
+
+		mocksJson = append(mocksJson, mockJson)
 	}
 
-	// Smocker API always expects a list of mocks to be sent
-	mocks := []json.RawMessage{mockJson}
 	body := &bytes.Buffer{}
-	err = json.NewEncoder(body).Encode(mocks)
+	err := json.NewEncoder(body).Encode(mocksJson)
 	if err != nil {
-		return nil, fmt.Errorf("unable to create request body bytes from mock. %w", err)
+		return nil, fmt.Errorf("unable to create request body bytes from mocks. %w", err)
 	}
 
 	return body, nil
@@ -168,7 +396,12 @@ func createAddMockRequestBody(mock MockDefinition) (*bytes.Buffer, error) {
 
 // ResetAllSessionsAndMocks Clears the Smocker server of all sessions and mocks. Leaving it in a clean state
 func (i Instance) ResetAllSessionsAndMocks() error {
-	resp, err := i.sendResetAllSessionsAndMocksRequest()
+	return i.ResetAllSessionsAndMocksContext(context.Background())
+}
+
+// ResetAllSessionsAndMocksContext Is ResetAllSessionsAndMocks with a context to cancel the request and set its deadline.
+func (i Instance) ResetAllSessionsAndMocksContext(ctx context.Context) error {
+	resp, err := i.sendResetAllSessionsAndMocksRequest(ctx)
 	if err != nil {
 		return fmt.Errorf("smockerclient unable to reset all the sessions and mocks. %w", err)
 	}
@@ -181,13 +414,13 @@ func (i Instance) ResetAllSessionsAndMocks() error {
 	return nil
 }
 
-func (i Instance) sendResetAllSessionsAndMocksRequest() (*http.Response, error) {
-	request, err := i.createResetAllSessionAndMocksRequest()
+func (i Instance) sendResetAllSessionsAndMocksRequest(ctx context.Context) (*http.Response, error) {
+	request, err := i.createResetAllSessionAndMocksRequest(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create request. %w", err)
 	}
 
-	resp, err := i.httpClient().Do(request)
+	resp, err := i.do(request)
 	if err != nil {
 		return nil, fmt.Errorf("unable to send request. %w", err)
 	}
@@ -195,9 +428,13 @@ func (i Instance) sendResetAllSessionsAndMocksRequest() (*http.Response, error)
 	return resp, nil
 }
 
-func (i Instance) createResetAllSessionAndMocksRequest() (*http.Request, error) {
-	url := i.url() + "/reset"
-	request, err := http.NewRequest(http.MethodPost, url, nil)
+func (i Instance) createResetAllSessionAndMocksRequest(ctx context.Context) (*http.Request, error) {
+	url, err := i.endpoint("/reset")
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, url, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -208,7 +445,12 @@ func (i Instance) createResetAllSessionAndMocksRequest() (*http.Request, error)
 // VerifyMocksInCurrentSession Checks all the mocks in the session have been called and that no other calls have been
 // made
 func (i Instance) VerifyMocksInCurrentSession() error {
-	resp, err := i.sendVerifySessionRequest()
+	return i.VerifyMocksInCurrentSessionContext(context.Background())
+}
+
+// VerifyMocksInCurrentSessionContext Is VerifyMocksInCurrentSession with a context to cancel the request and set its deadline.
+func (i Instance) VerifyMocksInCurrentSessionContext(ctx context.Context) error {
+	resp, err := i.sendVerifySessionRequest(ctx, "")
 	if err != nil {
 		return fmt.Errorf("smockerclient unable to verify the mocks in the current session. %w", err)
 	}
@@ -224,30 +466,26 @@ func (i Instance) VerifyMocksInCurrentSession() error {
 	}
 	defer resp.Body.Close()
 
-	var verifiedResp verifiedResponse
+	var verifiedResp VerificationResult
 	err = json.Unmarshal(bodyBytes, &verifiedResp)
 	if err != nil {
 		return fmt.Errorf("smockerclient unable to read json response to verify mocks in current session. %w", err)
 	}
 
-	if !verifiedResp.Mocks.AllUsed {
-		return fmt.Errorf("not all the mocks setup in the current session have been used. smocker response: %s", bodyBytes)
-	}
-
-	if !verifiedResp.History.Verified {
-		return fmt.Errorf("unexpected calls have been made in the current session. smocker response: %s", bodyBytes)
+	if !verifiedResp.Passed() {
+		return newVerificationError("", verifiedResp)
 	}
 
 	return nil
 }
 
-func (i Instance) sendVerifySessionRequest() (*http.Response, error) {
-	request, err := i.createVerifySessionRequest()
+func (i Instance) sendVerifySessionRequest(ctx context.Context, sessionID string) (*http.Response, error) {
+	request, err := i.createVerifySessionRequest(ctx, sessionID)
 	if err != nil {
 		return nil, err
 	}
 
-	response, err := i.httpClient().Do(request)
+	response, err := i.do(request)
 	if err != nil {
 		return nil, fmt.Errorf("unable to send request. %w", err)
 	}
@@ -255,42 +493,48 @@ func (i Instance) sendVerifySessionRequest() (*http.Response, error) {
 	return response, nil
 }
 
-func (i Instance) createVerifySessionRequest() (*http.Request, error) {
-	url := i.url() + "/sessions/verify"
-	request, err := http.NewRequest(http.MethodPost, url, nil)
+func (i Instance) createVerifySessionRequest(ctx context.Context, sessionID string) (*http.Request, error) {
+	url, err := i.endpoint("/sessions/verify")
 	if err != nil {
 		return nil, err
 	}
 
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	addSessionQueryParam(request, sessionID)
 	return request, nil
 }
 
+// addSessionQueryParam Targets the request at the session with the given id. Smocker uses the latest session when no
+// session is given.
+func addSessionQueryParam(req *http.Request, sessionID string) {
+	if sessionID == "" {
+		return
+	}
+
+	query := req.URL.Query()
+	query.Add("session", sessionID)
+	req.URL.RawQuery = query.Encode()
+}
+
 func handleNon200Response(resp *http.Response) error {
 	if resp.StatusCode == http.StatusOK {
 		return nil
 	}
 
+	statusErr := &StatusError{
+		StatusCode: resp.StatusCode,
+		Operation:  resp.Request.Method + " " + resp.Request.URL.Path,
+	}
+
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("unable to read response message received status:%d", resp.StatusCode)
+		return fmt.Errorf("unable to read response message. %w", statusErr)
 	}
 
-	return fmt.Errorf("received status:%d and message:%s", resp.StatusCode, body)
-
-}
-
-type verifiedResponse struct {
-	Mocks   verifiedResponseMocks   `json:"mocks"`
-	History verifiedResponseHistory `json:"history"`
-}
-
-type verifiedResponseMocks struct {
-	Verified bool   `json:"verified"`
-	AllUsed  bool   `json:"all_used"`
-	Message  string `json:"message"`
-}
-
-type verifiedResponseHistory struct {
-	Verified bool   `json:"verified"`
-	Message  string `json:"message"`
+	statusErr.Body = string(body)
+	return statusErr
 }
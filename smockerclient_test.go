@@ -1,12 +1,15 @@
 package smockerclient_test
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -79,6 +82,34 @@ func TestStartSession_WithNameThatNeedsUrlEscaping(t *testing.T) {
 	assert.Equal(t, 1, serverCallCount)
 }
 
+func TestStartSessionReturning(t *testing.T) {
+	sessionName := "my-new-session"
+
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Equal(t, "/sessions", r.URL.Path)
+
+				resp := `{
+					"id": "1d6d264b-4d13-4e0b-a51e-e44fc80eca9f",
+					"name": "` + sessionName + `"
+				  }`
+				_, err := w.Write([]byte(resp))
+				assert.NoError(t, err, "httptest server write failed")
+			},
+		),
+	)
+	defer server.Close()
+
+	smockerInstance := smockerclient.Instance{Url: server.URL}
+	session, err := smockerInstance.StartSessionReturning(sessionName)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "1d6d264b-4d13-4e0b-a51e-e44fc80eca9f", session.ID)
+	assert.Equal(t, sessionName, session.Name)
+}
+
 func TestStartSession_WhenServerDoesNotReturn200_ReturnsError(t *testing.T) {
 	sessionName := "my-new-session"
 
@@ -103,9 +134,10 @@ func TestAddMock(t *testing.T) {
 			func(w http.ResponseWriter, r *http.Request) {
 				serverCallCount++
 
-				assert.Equal(t, http.MethodPost, r.Method)
 				assert.Equal(t, "/mocks", r.URL.Path)
 
+				assert.Equal(t, http.MethodPost, r.Method)
+
 				contentType := r.Header.Get("Content-Type")
 				assert.Equal(t, jsonContentType, contentType)
 
@@ -130,6 +162,130 @@ func TestAddMock(t *testing.T) {
 	assert.Equal(t, 1, serverCallCount)
 }
 
+func TestAddMockReturningID(t *testing.T) {
+	serverCallCount := 0
+	sessionID := "Z9gF5kwSR"
+
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				serverCallCount++
+
+				assert.Equal(t, "/mocks", r.URL.Path)
+				assert.Equal(t, sessionID, r.URL.Query().Get("session"))
+
+				if r.Method == http.MethodGet {
+					_, err := w.Write([]byte(getAddedMocksBody()))
+					assert.NoError(t, err, "httptest server write failed")
+					return
+				}
+
+				assert.Equal(t, http.MethodPost, r.Method)
+
+				_, err := w.Write([]byte(`{"message": "Mocks registered successfully"}`))
+				assert.NoError(t, err, "httptest server write failed")
+			},
+		),
+	)
+	defer server.Close()
+
+	smockerInstance := smockerclient.Instance{Url: server.URL}
+	mockID, err := smockerInstance.AddMockReturningID(FakeMock{Json: `{"example": 1234}`}, smockerclient.InSession(sessionID))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Wq8ks4Rzq", mockID)
+	assert.Equal(t, 2, serverCallCount)
+}
+
+func TestAddMock_ReplacingExisting_SendsReset(t *testing.T) {
+	serverCallCount := 0
+
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				serverCallCount++
+
+				assert.Equal(t, "/mocks", r.URL.Path)
+				assert.False(t, r.URL.Query().Has("session"))
+
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Equal(t, "true", r.URL.Query().Get("reset"))
+
+				_, err := w.Write([]byte(`{"message": "Mocks registered successfully"}`))
+				assert.NoError(t, err, "httptest server write failed")
+			},
+		),
+	)
+	defer server.Close()
+
+	smockerInstance := smockerclient.Instance{Url: server.URL}
+	err := smockerInstance.AddMock(FakeMock{Json: `{"example": 1234}`}, smockerclient.ReplacingExisting())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, serverCallCount)
+}
+
+func TestAddMock_InSessionReplacingExisting_ReturnsErrorWithoutSending(t *testing.T) {
+	serverCallCount := 0
+
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				serverCallCount++
+			},
+		),
+	)
+	defer server.Close()
+
+	smockerInstance := smockerclient.Instance{Url: server.URL}
+	err := smockerInstance.AddMock(
+		FakeMock{Json: `{"example": 1234}`},
+		smockerclient.InSession("Z9gF5kwSR"),
+		smockerclient.ReplacingExisting(),
+	)
+
+	assert.EqualError(t, err, "smockerclient unable to add a new mock. ReplacingExisting can not be used with InSession as it removes every session")
+	assert.Equal(t, 0, serverCallCount)
+}
+
+func TestAddMock_WithNoOptions_DoesNotSendSessionOrReset(t *testing.T) {
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				assert.False(t, r.URL.Query().Has("session"))
+				assert.False(t, r.URL.Query().Has("reset"))
+			},
+		),
+	)
+	defer server.Close()
+
+	smockerInstance := smockerclient.Instance{Url: server.URL}
+	err := smockerInstance.AddMock(FakeMock{Json: `{"example": 1234}`})
+
+	assert.NoError(t, err)
+}
+
+func TestAddMockReturningID_WhenCreatedMockCannotBeFound_ReturnsError(t *testing.T) {
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodGet {
+					_, err := w.Write([]byte("[]"))
+					assert.NoError(t, err, "httptest server write failed")
+				}
+			},
+		),
+	)
+	defer server.Close()
+
+	smockerInstance := smockerclient.Instance{Url: server.URL}
+	mockID, err := smockerInstance.AddMockReturningID(FakeMock{Json: `{"example": 1234}`})
+
+	assert.Empty(t, mockID)
+	assert.ErrorIs(t, err, smockerclient.ErrMockIDUnknown)
+	assert.EqualError(t, err, "smockerclient added mock but was unable to find its id. expected at least 1 mocks in the session but found 0")
+}
+
 func TestAddMock_WhenMockJsonConversionErrors_ReturnsError(t *testing.T) {
 	mockError := errors.New("fails mock json conversion")
 	fakeMock := FakeMock{Error: mockError}
@@ -166,6 +322,116 @@ func TestAddMock_WhenServerDoesNotReturn200_ReturnsError(t *testing.T) {
 	assert.EqualError(t, err, "smockerclient unable to add mock. received status:400 and message:400 Bad Request")
 }
 
+func TestAddMocks(t *testing.T) {
+	serverCallCount := 0
+	firstMock := FakeMock{Json: `{"example": 1}`}
+	secondMock := FakeMock{Json: `{"example": 2}`}
+	expectedJson := `[{"example": 1}, {"example": 2}]`
+
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				serverCallCount++
+
+				assert.Equal(t, "/mocks", r.URL.Path)
+
+				if r.Method == http.MethodGet {
+					_, err := w.Write([]byte(getAddedMocksBody()))
+					assert.NoError(t, err, "httptest server write failed")
+					return
+				}
+
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Equal(t, jsonContentType, r.Header.Get("Content-Type"))
+
+				body, err := io.ReadAll(r.Body)
+				assert.NoError(t, err)
+				assert.JSONEq(t, expectedJson, string(body))
+
+				_, err = w.Write([]byte(`{"message": "Mocks registered successfully"}`))
+				assert.NoError(t, err, "httptest server write failed")
+			},
+		),
+	)
+	defer server.Close()
+
+	smockerInstance := smockerclient.Instance{Url: server.URL}
+	mockIDs, err := smockerInstance.AddMocks(firstMock, secondMock)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Pm3ks4Rza", "Wq8ks4Rzq"}, mockIDs)
+	assert.Equal(t, 2, serverCallCount)
+}
+
+func TestAddMocks_WithNoMocks_DoesNotCallServer(t *testing.T) {
+	serverCallCount := 0
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				serverCallCount++
+			},
+		),
+	)
+	defer server.Close()
+
+	smockerInstance := smockerclient.Instance{Url: server.URL}
+	mockIDs, err := smockerInstance.AddMocks()
+
+	assert.NoError(t, err)
+	assert.Empty(t, mockIDs)
+	assert.Equal(t, 0, serverCallCount)
+}
+
+func TestAddMocks_WhenMockJsonConversionErrors_ReturnsErrorNamingTheMock(t *testing.T) {
+	mockError := errors.New("fails mock json conversion")
+
+	serverCallCount := 0
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				serverCallCount++
+			},
+		),
+	)
+	defer server.Close()
+
+	smockerInstance := smockerclient.Instance{Url: server.URL}
+	_, err := smockerInstance.AddMocks(FakeMock{Json: `{}`}, FakeMock{Error: mockError})
+
+	assert.Equal(t, 0, serverCallCount)
+	assert.ErrorIs(t, err, mockError)
+	assert.EqualError(t, err, "smockerclient unable to add 2 new mocks. unable to create request. mock definition 1 is invalid. unable to convert mock to json when running ToMockDefinitionJson. fails mock json conversion")
+}
+
+func TestAddMocks_WhenMockJsonIsInvalid_ReturnsErrorNamingTheMock(t *testing.T) {
+	serverCallCount := 0
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				serverCallCount++
+			},
+		),
+	)
+	defer server.Close()
+
+	smockerInstance := smockerclient.Instance{Url: server.URL}
+	_, err := smockerInstance.AddMocks(FakeMock{Json: `{}`}, FakeMock{Json: `{}`}, FakeMock{Json: `{"example":`})
+
+	assert.Equal(t, 0, serverCallCount)
+	assert.EqualError(t, err, `smockerclient unable to add 3 new mocks. unable to create request. mock definition 2 is invalid. ToMockDefinitionJson returned invalid json: {"example":`)
+}
+
+func TestAddMocks_WhenServerDoesNotReturn200_ReturnsError(t *testing.T) {
+	server, serverCallCount := newBadResponseServer(t)
+	defer server.Close()
+
+	smockerInstance := smockerclient.Instance{Url: server.URL}
+	_, err := smockerInstance.AddMocks(FakeMock{Json: `{}`}, FakeMock{Json: `{}`})
+
+	assert.Equal(t, 1, *serverCallCount)
+	assert.EqualError(t, err, "smockerclient unable to add 2 new mocks. received status:400 and message:400 Bad Request")
+}
+
 func TestResetAllSessionsAndMocks(t *testing.T) {
 	serverCallCount := 0
 
@@ -333,6 +599,164 @@ func TestVerifyMocksInLatestSession_WhenServerDoesNotReturn200_ReturnsError(t *t
 	assert.EqualError(t, err, "smockerclient unable to verify mocks in current session. received status:400 and message:400 Bad Request")
 }
 
+func TestContextVariants_WhenContextIsDone_ReturnError(t *testing.T) {
+	server := newHangingServer()
+	defer server.Close()
+
+	smockerInstance := smockerclient.Instance{Url: server.URL}
+	calls := map[string]func(ctx context.Context) error{
+		"StartSessionContext": func(ctx context.Context) error {
+			return smockerInstance.StartSessionContext(ctx, "my-new-session")
+		},
+		"AddMockContext": func(ctx context.Context) error {
+			return smockerInstance.AddMockContext(ctx, FakeMock{Json: `{"example": 1234}`})
+		},
+		"ResetAllSessionsAndMocksContext": func(ctx context.Context) error {
+			return smockerInstance.ResetAllSessionsAndMocksContext(ctx)
+		},
+		"VerifyMocksInCurrentSessionContext": func(ctx context.Context) error {
+			return smockerInstance.VerifyMocksInCurrentSessionContext(ctx)
+		},
+	}
+
+	for name, call := range calls {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+
+			err := call(ctx)
+
+			assert.ErrorIs(t, err, context.DeadlineExceeded)
+		})
+	}
+}
+
+// newHangingServer Creates a server that never responds, until the request is cancelled. The body is read first, as the
+// server only notices the client has gone once the body has been consumed.
+func newHangingServer() *httptest.Server {
+	return httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				_, _ = io.Copy(io.Discard, r.Body)
+				<-r.Context().Done()
+			},
+		),
+	)
+}
+
+func TestUrl_WithPathPrefix_JoinsAdminPathsOntoThePrefix(t *testing.T) {
+	for _, prefix := range []string{"/smocker-admin", "/smocker-admin/"} {
+		t.Run(prefix, func(t *testing.T) {
+			var paths []string
+
+			server := httptest.NewServer(
+				http.HandlerFunc(
+					func(w http.ResponseWriter, r *http.Request) {
+						paths = append(paths, r.URL.Path)
+						if r.URL.Path == "/smocker-admin/sessions" {
+							_, err := w.Write([]byte(`{"id": "Z9gF5kwSR", "name": "my-session"}`))
+							assert.NoError(t, err, "httptest server write failed")
+						}
+					},
+				),
+			)
+			defer server.Close()
+
+			smockerInstance := smockerclient.Instance{Url: server.URL + prefix}
+			err := smockerInstance.StartSession("my-session")
+			assert.NoError(t, err)
+
+			err = smockerInstance.ResetAllSessionsAndMocks()
+			assert.NoError(t, err)
+
+			assert.Equal(t, []string{"/smocker-admin/sessions", "/smocker-admin/reset"}, paths)
+		})
+	}
+}
+
+func TestValidate_WhenUrlIsMalformed_ReturnsErrInvalidUrl(t *testing.T) {
+	tests := map[string]struct {
+		url           string
+		expectedError string
+	}{
+		"unparsable": {
+			url:           "http://%zz",
+			expectedError: `smockerclient url "http://%zz" is invalid. parse "http://%zz": invalid URL escape "%zz"`,
+		},
+		"missing scheme": {
+			url:           "localhost:8081",
+			expectedError: `smockerclient url "localhost:8081" is invalid. it must use the http or https scheme`,
+		},
+		"missing host": {
+			url:           "http:///smocker-admin",
+			expectedError: `smockerclient url "http:///smocker-admin" is invalid. it must include a host`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			smockerInstance := smockerclient.Instance{Url: test.url}
+
+			err := smockerInstance.Validate()
+			assert.ErrorIs(t, err, smockerclient.ErrInvalidUrl)
+			assert.EqualError(t, err, test.expectedError)
+
+			err = smockerInstance.ResetAllSessionsAndMocks()
+			assert.ErrorIs(t, err, smockerclient.ErrInvalidUrl)
+			assert.ErrorContains(t, err, "smockerclient unable to reset all the sessions and mocks. unable to create request. "+test.expectedError)
+		})
+	}
+}
+
+func TestValidate_WhenUrlIsValid_ReturnsNil(t *testing.T) {
+	for _, url := range []string{"", "http://localhost:8081", "https://example.com/smocker-admin"} {
+		smockerInstance := smockerclient.Instance{Url: url}
+		assert.NoError(t, smockerInstance.Validate(), url)
+	}
+}
+
+// getAddedMocksBody The mocks in a session after two new mocks, Pm3ks4Rza and Wq8ks4Rzq, have been added to it.
+func getAddedMocksBody() string {
+	return `[
+    {
+        "request": {
+            "path": {"matcher": "ShouldEqual", "value": "/healthcheck"},
+            "method": {"matcher": "ShouldEqual", "value": "GET"}
+        },
+        "response": {"status": 200},
+        "context": {},
+        "state": {
+            "id": "lkd8ks4Rz",
+            "times_count": 3,
+            "locked": false,
+            "creation_date": "2023-04-26T14:41:40Z"
+        }
+    },
+    {
+        "request": {"path": {"matcher": "ShouldEqual", "value": "/example"}},
+        "response": {"status": 200},
+        "context": {},
+        "state": {
+            "id": "Wq8ks4Rzq",
+            "times_count": 0,
+            "locked": false,
+            "creation_date": "2023-04-26T14:41:44Z"
+        }
+    },
+    {
+        "request": {"path": {"matcher": "ShouldEqual", "value": "/example"}},
+        "response": {"status": 200},
+        "context": {},
+        "state": {
+            "id": "Pm3ks4Rza",
+            "times_count": 0,
+            "locked": false,
+            "creation_date": "2023-04-26T14:41:43Z"
+        }
+    }
+]`
+}
+
 func newBadResponseServer(t *testing.T) (*httptest.Server, *int) {
 	serverCallCount := 0
 
@@ -643,3 +1067,30 @@ type FakeMock struct {
 func (fm FakeMock) ToMockDefinitionJson() ([]byte, error) {
 	return []byte(fm.Json), fm.Error
 }
+
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (fn roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return fn(req)
+}
+
+func TestAddMock_WhenRoundTripperDoesNotSetResponseRequest_ReturnsStatusError(t *testing.T) {
+	httpClient := &http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusBadRequest,
+				Header:     http.Header{},
+				Body:       io.NopCloser(strings.NewReader("bad mock")),
+			}, nil
+		}),
+	}
+
+	smockerInstance := smockerclient.Instance{Url: "http://localhost:8081", HttpClient: httpClient}
+	err := smockerInstance.AddMock(FakeMock{Json: "{}"})
+
+	var statusErr *smockerclient.StatusError
+	assert.ErrorAs(t, err, &statusErr)
+	assert.Equal(t, http.StatusBadRequest, statusErr.StatusCode)
+	assert.Equal(t, "bad mock", statusErr.Body)
+	assert.Equal(t, "POST /mocks", statusErr.Operation)
+}
@@ -0,0 +1,170 @@
+package smockerclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// SessionSummary The call graph of a session, describing the calls made between the client and each mocked host.
+type SessionSummary struct {
+	// Nodes The participants of the calls, in the order they first appear.
+	Nodes []SummaryNode
+	// Edges The calls and responses between the participants, in the order they happened.
+	Edges []SummaryEdge
+}
+
+type SummaryNode struct {
+	Name string
+}
+
+type SummaryEdge struct {
+	Type    string    `json:"type"`
+	Message string    `json:"message"`
+	From    string    `json:"from"`
+	To      string    `json:"to"`
+	Date    time.Time `json:"date"`
+}
+
+// SessionSummary Gets the call graph of the session with the given id. An empty session id gets the call graph of the
+// latest session.
+func (i Instance) SessionSummary(sessionID string) (SessionSummary, error) {
+	return i.SessionSummaryContext(context.Background(), sessionID)
+}
+
+// SessionSummaryContext Is SessionSummary with a context to cancel the request and set its deadline.
+func (i Instance) SessionSummaryContext(ctx context.Context, sessionID string) (SessionSummary, error) {
+	resp, err := i.sendSessionSummaryRequest(ctx, sessionID)
+	if err != nil {
+		return SessionSummary{}, fmt.Errorf("smockerclient unable to summarise session %s. %w", sessionID, err)
+	}
+	defer resp.Body.Close()
+
+	err = handleNon200Response(resp)
+	if err != nil {
+		return SessionSummary{}, fmt.Errorf("smockerclient unable to summarise session %s. %w", sessionID, err)
+	}
+
+	var edges []SummaryEdge
+	err = json.NewDecoder(resp.Body).Decode(&edges)
+	if err != nil {
+		return SessionSummary{}, fmt.Errorf("smockerclient unable to read json response to summarise session %s. %w", sessionID, err)
+	}
+
+	return newSessionSummary(edges), nil
+}
+
+func newSessionSummary(edges []SummaryEdge) SessionSummary {
+	summary := SessionSummary{Edges: edges}
+
+	seen := map[string]bool{}
+	for _, edge := range edges {
+		for _, name := range []string{edge.From, edge.To} {
+			if !seen[name] {
+				seen[name] = true
+				summary.Nodes = append(summary.Nodes, SummaryNode{Name: name})
+			}
+		}
+	}
+
+	return summary
+}
+
+func (i Instance) sendSessionSummaryRequest(ctx context.Context, sessionID string) (*http.Response, error) {
+	req, err := i.createSessionSummaryRequest(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create request. %w", err)
+	}
+
+	resp, err := i.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("unable to send request. %w", err)
+	}
+
+	return resp, nil
+}
+
+// createSessionSummaryRequest Smocker serves the call graph from /history/summary, /sessions/summary only lists the
+// sessions.
+func (i Instance) createSessionSummaryRequest(ctx context.Context, sessionID string) (*http.Request, error) {
+	url, err := i.endpoint("/history/summary")
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	addSessionQueryParam(req, sessionID)
+	return req, nil
+}
+
+// Mermaid Renders the summary as a Mermaid sequence diagram.
+func (s SessionSummary) Mermaid() string {
+	var sb strings.Builder
+	sb.WriteString("sequenceDiagram\n")
+
+	ids := s.nodeIDs()
+	for _, node := range s.Nodes {
+		fmt.Fprintf(&sb, "    participant %s as %s\n", ids[node.Name], escapeMermaid(node.Name))
+	}
+
+	for _, edge := range s.Edges {
+		arrow := "->>"
+		if edge.Type == "response" {
+			arrow = "-->>"
+		}
+		fmt.Fprintf(&sb, "    %s%s%s: %s\n", ids[edge.From], arrow, ids[edge.To], escapeMermaid(edge.Message))
+	}
+
+	return sb.String()
+}
+
+// DOT Renders the summary as a Graphviz DOT directed graph, with the edges numbered in the order they happened.
+func (s SessionSummary) DOT() string {
+	var sb strings.Builder
+	sb.WriteString("digraph session {\n")
+
+	ids := s.nodeIDs()
+	for _, node := range s.Nodes {
+		fmt.Fprintf(&sb, "    %s [label=%s];\n", ids[node.Name], quoteDOT(node.Name))
+	}
+
+	for index, edge := range s.Edges {
+		label := fmt.Sprintf("%d. %s", index+1, edge.Message)
+		style := ""
+		if edge.Type == "response" {
+			style = ", style=dashed"
+		}
+		fmt.Fprintf(&sb, "    %s -> %s [label=%s%s];\n", ids[edge.From], ids[edge.To], quoteDOT(label), style)
+	}
+
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+func (s SessionSummary) nodeIDs() map[string]string {
+	ids := make(map[string]string, len(s.Nodes))
+	for index, node := range s.Nodes {
+		ids[node.Name] = fmt.Sprintf("n%d", index)
+	}
+
+	return ids
+}
+
+var mermaidEscaper = strings.NewReplacer(";", "#59;", "#", "#35;", "\n", "<br/>")
+
+func escapeMermaid(text string) string {
+	return mermaidEscaper.Replace(text)
+}
+
+var dotEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func quoteDOT(text string) string {
+	return `"` + dotEscaper.Replace(text) + `"`
+}
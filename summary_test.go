@@ -0,0 +1,115 @@
+package smockerclient_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/churmd/smockerclient"
+)
+
+func TestSessionSummary(t *testing.T) {
+	serverCallCount := 0
+	sessionID := "Z9gF5kwSR"
+
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				serverCallCount++
+
+				assert.Equal(t, http.MethodGet, r.Method)
+				assert.Equal(t, "/history/summary", r.URL.Path)
+				assert.Equal(t, sessionID, r.URL.Query().Get("session"))
+
+				_, err := w.Write([]byte(getSummaryBody()))
+				assert.NoError(t, err, "httptest server write failed")
+			},
+		),
+	)
+	defer server.Close()
+
+	smockerInstance := smockerclient.Instance{Url: server.URL}
+	summary, err := smockerInstance.SessionSummary(sessionID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, serverCallCount)
+	assert.Equal(t, createSummary(), summary)
+}
+
+func TestSessionSummary_WhenServerDoesNotReturn200_ReturnsError(t *testing.T) {
+	server, serverCallCount := newBadResponseServer(t)
+	defer server.Close()
+
+	smockerInstance := smockerclient.Instance{Url: server.URL}
+	_, err := smockerInstance.SessionSummary("Z9gF5kwSR")
+
+	assert.Equal(t, 1, *serverCallCount)
+	assert.EqualError(t, err, "smockerclient unable to summarise session Z9gF5kwSR. received status:400 and message:400 Bad Request")
+}
+
+func TestSessionSummary_Mermaid(t *testing.T) {
+	expected := `sequenceDiagram
+    participant n0 as Client
+    participant n1 as Smocker
+    n0->>n1: POST /orders#59; retry #35;2
+    n1-->>n0: 201
+`
+
+	assert.Equal(t, expected, createSummary().Mermaid())
+}
+
+func TestSessionSummary_DOT(t *testing.T) {
+	expected := `digraph session {
+    n0 [label="Client"];
+    n1 [label="Smocker"];
+    n0 -> n1 [label="1. POST /orders; retry #2"];
+    n1 -> n0 [label="2. 201", style=dashed];
+}
+`
+
+	assert.Equal(t, expected, createSummary().DOT())
+}
+
+func createSummary() smockerclient.SessionSummary {
+	return smockerclient.SessionSummary{
+		Nodes: []smockerclient.SummaryNode{{Name: "Client"}, {Name: "Smocker"}},
+		Edges: []smockerclient.SummaryEdge{
+			{
+				Type:    "request",
+				Message: "POST /orders; retry #2",
+				From:    "Client",
+				To:      "Smocker",
+				Date:    time.Date(2023, 4, 26, 14, 42, 53, 0, time.UTC),
+			},
+			{
+				Type:    "response",
+				Message: "201",
+				From:    "Smocker",
+				To:      "Client",
+				Date:    time.Date(2023, 4, 26, 14, 42, 54, 0, time.UTC),
+			},
+		},
+	}
+}
+
+func getSummaryBody() string {
+	return `[
+    {
+        "type": "request",
+        "message": "POST /orders; retry #2",
+        "from": "Client",
+        "to": "Smocker",
+        "date": "2023-04-26T14:42:53Z"
+    },
+    {
+        "type": "response",
+        "message": "201",
+        "from": "Smocker",
+        "to": "Client",
+        "date": "2023-04-26T14:42:54Z"
+    }
+]`
+}
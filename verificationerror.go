@@ -0,0 +1,397 @@
+package smockerclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/churmd/smockerclient/mock"
+)
+
+const bodyExcerptLength = 200
+
+// VerificationError The mocks in a session were not all used or unexpected calls were made. Error returns a multi-line
+// report of the unused mocks and unexpected calls.
+type VerificationError struct {
+	// SessionID The session that was verified, empty for the current session.
+	SessionID       string
+	Result          VerificationResult
+	UnusedMocks     []UnusedMock
+	UnexpectedCalls []UnexpectedCall
+}
+
+// UnusedMock A mock that was never called.
+type UnusedMock struct {
+	ID     string
+	Method string
+	Path   string
+	// RemainingTimes How many more times the mock can be called, not set when the mock is Unlimited.
+	RemainingTimes int
+	Unlimited      bool
+}
+
+// UnexpectedCall A call received by the Smocker mock server that did not match a mock.
+type UnexpectedCall struct {
+	Method      string
+	Path        string
+	QueryParams map[string][]string
+	Headers     map[string][]string
+	// BodyExcerpt The start of the request body.
+	BodyExcerpt string
+	// ClosestMock The unused or exceeded mock that the call most nearly matched, nil when there are none.
+	ClosestMock *ClosestMock
+}
+
+// ClosestMock A suggestion of which mock an unexpected call was meant to match, with the fields that did not match.
+type ClosestMock struct {
+	ID          string
+	Method      string
+	Path        string
+	Differences []FieldDifference
+}
+
+// FieldDifference A field of the call that did not match the mock.
+type FieldDifference struct {
+	Field    string
+	Expected string
+	Actual   string
+}
+
+func newVerificationError(sessionID string, result VerificationResult) *VerificationError {
+	verificationErr := &VerificationError{
+		SessionID: sessionID,
+		Result:    result,
+	}
+
+	for _, unused := range result.Mocks.Unused {
+		verificationErr.UnusedMocks = append(verificationErr.UnusedMocks, newUnusedMock(unused))
+	}
+
+	candidates := slices.Concat(result.Mocks.Unused, result.Mocks.Failures)
+	for _, failure := range result.History.Failures {
+		callCandidates := slices.Concat(candidates, nearestMocks(failure))
+		verificationErr.UnexpectedCalls = append(verificationErr.UnexpectedCalls, newUnexpectedCall(failure, callCandidates))
+	}
+
+	return verificationErr
+}
+
+func newUnusedMock(unused Mock) UnusedMock {
+	unusedMock := UnusedMock{
+		ID:        unused.State.ID,
+		Method:    unused.Definition.Request.Method,
+		Path:      unused.Definition.Request.Path,
+		Unlimited: true,
+	}
+
+	if unused.Definition.Context != nil && unused.Definition.Context.Times > 0 {
+		unusedMock.Unlimited = false
+		unusedMock.RemainingTimes = unused.Definition.Context.Times - unused.State.TimesCount
+	}
+
+	return unusedMock
+}
+
+// nearestMocks Gets the mocks Smocker reports as nearest to the call, which it includes when a matching mock has been
+// called more times than it allows.
+func nearestMocks(failure HistoryEntry) []Mock {
+	var body struct {
+		Nearest []Mock `json:"nearest"`
+	}
+
+	err := json.Unmarshal(failure.Response.Body, &body)
+	if err != nil {
+		return nil
+	}
+
+	return body.Nearest
+}
+
+func newUnexpectedCall(failure HistoryEntry, candidates []Mock) UnexpectedCall {
+	return UnexpectedCall{
+		Method:      failure.Request.Method,
+		Path:        failure.Request.Path,
+		QueryParams: failure.Request.QueryParams,
+		Headers:     failure.Request.Headers,
+		BodyExcerpt: excerpt(string(failure.Request.BodyBytes())),
+		ClosestMock: closestMock(failure.Request, candidates),
+	}
+}
+
+func closestMock(request HistoryRequest, candidates []Mock) *ClosestMock {
+	var closest *ClosestMock
+	seen := map[string]bool{}
+
+	for _, candidate := range candidates {
+		if seen[candidate.State.ID] {
+			continue
+		}
+		seen[candidate.State.ID] = true
+
+		differences := diffRequest(candidate.Definition.Request, request)
+		if isExceeded(candidate) {
+			differences = append(differences, FieldDifference{
+				Field:    "times",
+				Expected: fmt.Sprintf("at most %d calls", candidate.Definition.Context.Times),
+				Actual:   fmt.Sprintf("%d calls", candidate.State.TimesCount),
+			})
+		}
+
+		if closest == nil || len(differences) < len(closest.Differences) {
+			closest = &ClosestMock{
+				ID:          candidate.State.ID,
+				Method:      candidate.Definition.Request.Method,
+				Path:        candidate.Definition.Request.Path,
+				Differences: differences,
+			}
+		}
+	}
+
+	return closest
+}
+
+func isExceeded(candidate Mock) bool {
+	context := candidate.Definition.Context
+	return context != nil && context.Times > 0 && candidate.State.TimesCount >= context.Times
+}
+
+// diffRequest Compares the call against the mock's request using the common Smocker matchers. Only the values of the
+// query param and header matchers are known, so the comparison is a best effort suggestion rather than exactly how
+// Smocker matches.
+func diffRequest(expected mock.Request, actual HistoryRequest) []FieldDifference {
+	var differences []FieldDifference
+
+	if expected.Method != "" && !methodMatches(expected, actual.Method) {
+		differences = append(differences, FieldDifference{
+			Field:    matcherField("method", expected.MethodMatcher),
+			Expected: expected.Method,
+			Actual:   actual.Method,
+		})
+	}
+
+	if expected.Path != "" && !stringMatches(expected.PathMatcher, expected.Path, actual.Path) {
+		differences = append(differences, FieldDifference{
+			Field:    matcherField("path", expected.PathMatcher),
+			Expected: expected.Path,
+			Actual:   actual.Path,
+		})
+	}
+
+	for _, key := range sortedKeys(expected.QueryParams) {
+		actualValues := actual.QueryParams[key]
+		if !containsAll(actualValues, expected.QueryParams[key]) {
+			differences = append(differences, FieldDifference{
+				Field:    "query param " + key,
+				Expected: strings.Join(expected.QueryParams[key], ", "),
+				Actual:   describeValues(actualValues),
+			})
+		}
+	}
+
+	for _, key := range sortedKeys(expected.Headers) {
+		actualValues := http.Header(actual.Headers).Values(key)
+		if !containsAll(actualValues, expected.Headers[key]) {
+			differences = append(differences, FieldDifference{
+				Field:    "header " + key,
+				Expected: strings.Join(expected.Headers[key], ", "),
+				Actual:   describeValues(actualValues),
+			})
+		}
+	}
+
+	body := string(actual.BodyBytes())
+	if expected.Body != nil && !bodyMatches(*expected.Body, body) {
+		differences = append(differences, FieldDifference{
+			Field:    "body " + expected.Body.Matcher,
+			Expected: excerpt(expected.Body.Value),
+			Actual:   excerpt(body),
+		})
+	}
+
+	return differences
+}
+
+// bodyMatches Checks the body against the common Smocker body matchers, any other matcher is assumed to match.
+func bodyMatches(expected mock.RequestBody, body string) bool {
+	switch expected.Matcher {
+	case "":
+		return true
+	case "ShouldEqualJSON":
+		return jsonEqual(expected.Value, body)
+	default:
+		return stringMatches(expected.Matcher, expected.Value, body)
+	}
+}
+
+// methodMatches Checks the method like stringMatches, except a plain method is compared ignoring case.
+func methodMatches(expected mock.Request, method string) bool {
+	if expected.MethodMatcher == "" {
+		return strings.EqualFold(expected.Method, method)
+	}
+
+	return stringMatches(expected.MethodMatcher, expected.Method, method)
+}
+
+// stringMatches Checks the value against the common Smocker string matchers, where an empty matcher is ShouldEqual. Any
+// other matcher is assumed to match.
+func stringMatches(matcher, expected, actual string) bool {
+	switch matcher {
+	case "", "ShouldEqual":
+		return expected == actual
+	case "ShouldContainSubstring":
+		return strings.Contains(actual, expected)
+	case "ShouldMatch":
+		matched, err := regexp.MatchString(expected, actual)
+		return err != nil || matched
+	default:
+		return true
+	}
+}
+
+// matcherField Names the field with its matcher when it is not compared for equality, e.g. path ShouldMatch.
+func matcherField(field, matcher string) string {
+	if matcher == "" {
+		return field
+	}
+
+	return field + " " + matcher
+}
+
+func jsonEqual(expected, actual string) bool {
+	var expectedValue, actualValue any
+	if json.Unmarshal([]byte(expected), &expectedValue) != nil {
+		return expected == actual
+	}
+	if json.Unmarshal([]byte(actual), &actualValue) != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(expectedValue, actualValue)
+}
+
+func containsAll(actual, expected []string) bool {
+	for _, value := range expected {
+		if !slices.Contains(actual, value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func describeValues(values []string) string {
+	if len(values) == 0 {
+		return "<missing>"
+	}
+
+	return strings.Join(values, ", ")
+}
+
+func sortedKeys(values map[string][]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+func excerpt(text string) string {
+	if len(text) <= bodyExcerptLength {
+		return text
+	}
+
+	return text[:bodyExcerptLength] + "..."
+}
+
+func (e *VerificationError) Error() string {
+	session := "the current session"
+	if e.SessionID != "" {
+		session = "session " + e.SessionID
+	}
+
+	var sections []string
+	if !e.Result.Mocks.AllUsed {
+		sections = append(sections, e.unusedMocksReport(session))
+	}
+
+	if !e.Result.History.Verified {
+		sections = append(sections, e.unexpectedCallsReport(session))
+	}
+
+	return strings.Join(sections, "\n")
+}
+
+func (e *VerificationError) unusedMocksReport(session string) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "not all the mocks setup in %s have been used:", session)
+
+	if len(e.UnusedMocks) == 0 {
+		fmt.Fprintf(&sb, "\n  %s", e.Result.Mocks.Message)
+	}
+
+	for _, unused := range e.UnusedMocks {
+		remaining := "no call limit"
+		if !unused.Unlimited {
+			remaining = fmt.Sprintf("%d calls remaining", unused.RemainingTimes)
+		}
+		fmt.Fprintf(&sb, "\n  - %s %s (mock %s) %s", unused.Method, unused.Path, unused.ID, remaining)
+	}
+
+	return sb.String()
+}
+
+func (e *VerificationError) unexpectedCallsReport(session string) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "unexpected calls have been made in %s:", session)
+
+	if len(e.UnexpectedCalls) == 0 {
+		fmt.Fprintf(&sb, "\n  %s", e.Result.History.Message)
+	}
+
+	for _, call := range e.UnexpectedCalls {
+		fmt.Fprintf(&sb, "\n  - %s %s", call.Method, pathWithQuery(call.Path, call.QueryParams))
+
+		if len(call.Headers) > 0 {
+			fmt.Fprintf(&sb, "\n      headers: %s", formatHeaders(call.Headers))
+		}
+
+		if call.BodyExcerpt != "" {
+			fmt.Fprintf(&sb, "\n      body: %s", call.BodyExcerpt)
+		}
+
+		if call.ClosestMock != nil {
+			closest := call.ClosestMock
+			fmt.Fprintf(&sb, "\n      closest mock: %s %s (mock %s)", closest.Method, closest.Path, closest.ID)
+			for _, difference := range closest.Differences {
+				fmt.Fprintf(&sb, "\n        %s: expected %q but got %q", difference.Field, difference.Expected, difference.Actual)
+			}
+		}
+	}
+
+	return sb.String()
+}
+
+func pathWithQuery(path string, queryParams map[string][]string) string {
+	if len(queryParams) == 0 {
+		return path
+	}
+
+	return path + "?" + url.Values(queryParams).Encode()
+}
+
+func formatHeaders(headers map[string][]string) string {
+	formatted := make([]string, 0, len(headers))
+	for _, key := range sortedKeys(headers) {
+		formatted = append(formatted, key+": "+strings.Join(headers[key], ", "))
+	}
+
+	return strings.Join(formatted, "; ")
+}
@@ -0,0 +1,279 @@
+package smockerclient_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/churmd/smockerclient"
+)
+
+func TestVerificationError_ReportsUnusedMocksAndUnexpectedCallsWithClosestMock(t *testing.T) {
+	server := newVerifyServer(t, getVerifyBodyWithUnusedMocksAndUnmatchedCall())
+	defer server.Close()
+
+	smockerInstance := smockerclient.Instance{Url: server.URL}
+	_, err := smockerInstance.VerifySession("Z9gF5kwSR")
+
+	var verificationErr *smockerclient.VerificationError
+	assert.ErrorAs(t, err, &verificationErr)
+
+	expectedUnused := []smockerclient.UnusedMock{
+		{ID: "orders1", Method: "POST", Path: "/orders", RemainingTimes: 1},
+		{ID: "health1", Method: "GET", Path: "/health", Unlimited: true},
+	}
+	assert.Equal(t, expectedUnused, verificationErr.UnusedMocks)
+
+	expectedCalls := []smockerclient.UnexpectedCall{
+		{
+			Method:      "POST",
+			Path:        "/orders",
+			QueryParams: map[string][]string{"dry_run": {"true"}},
+			Headers:     map[string][]string{"Content-Type": {"application/json"}},
+			BodyExcerpt: `{"id": 5678}`,
+			ClosestMock: &smockerclient.ClosestMock{
+				ID:     "orders1",
+				Method: "POST",
+				Path:   "/orders",
+				Differences: []smockerclient.FieldDifference{
+					{Field: "header Idempotency-Key", Expected: "abc", Actual: "<missing>"},
+					{Field: "body ShouldEqualJSON", Expected: `{"id": 1234}`, Actual: `{"id": 5678}`},
+				},
+			},
+		},
+	}
+	assert.Equal(t, expectedCalls, verificationErr.UnexpectedCalls)
+
+	expectedReport := `not all the mocks setup in session Z9gF5kwSR have been used:
+  - POST /orders (mock orders1) 1 calls remaining
+  - GET /health (mock health1) no call limit
+unexpected calls have been made in session Z9gF5kwSR:
+  - POST /orders?dry_run=true
+      headers: Content-Type: application/json
+      body: {"id": 5678}
+      closest mock: POST /orders (mock orders1)
+        header Idempotency-Key: expected "abc" but got "<missing>"
+        body ShouldEqualJSON: expected "{\"id\": 1234}" but got "{\"id\": 5678}"`
+	assert.EqualError(t, err, expectedReport)
+}
+
+func TestVerificationError_SuggestsExceededMockFromSmockerNearest(t *testing.T) {
+	server := newVerifyServer(t, getVerifyBodyWhenExtraCallExceedsMock())
+	defer server.Close()
+
+	smockerInstance := smockerclient.Instance{Url: server.URL}
+	err := smockerInstance.VerifyMocksInCurrentSession()
+
+	var verificationErr *smockerclient.VerificationError
+	assert.ErrorAs(t, err, &verificationErr)
+	assert.Empty(t, verificationErr.UnusedMocks)
+
+	expectedClosest := &smockerclient.ClosestMock{
+		ID:     "test1",
+		Method: "GET",
+		Path:   "/test",
+		Differences: []smockerclient.FieldDifference{
+			{Field: "times", Expected: "at most 1 calls", Actual: "2 calls"},
+		},
+	}
+	assert.Len(t, verificationErr.UnexpectedCalls, 1)
+	assert.Equal(t, expectedClosest, verificationErr.UnexpectedCalls[0].ClosestMock)
+
+	expectedReport := `unexpected calls have been made in the current session:
+  - GET /test
+      closest mock: GET /test (mock test1)
+        times: expected "at most 1 calls" but got "2 calls"`
+	assert.EqualError(t, err, expectedReport)
+}
+
+func TestVerificationError_WhenNoMocksToCompare_HasNoClosestMock(t *testing.T) {
+	server := newVerifyServer(t, getVerifyBodyWhenExtraCallsHaveBeenMade())
+	defer server.Close()
+
+	smockerInstance := smockerclient.Instance{Url: server.URL}
+	err := smockerInstance.VerifyMocksInCurrentSession()
+
+	var verificationErr *smockerclient.VerificationError
+	assert.ErrorAs(t, err, &verificationErr)
+	assert.Len(t, verificationErr.UnexpectedCalls, 1)
+	assert.Nil(t, verificationErr.UnexpectedCalls[0].ClosestMock)
+	assert.NotContains(t, err.Error(), "closest mock")
+}
+
+func TestVerificationError_WhenMockUsesMatchers_ComparesCallsWithTheMatchers(t *testing.T) {
+	server := newVerifyServer(t, getVerifyBodyWithPathMatcherMock())
+	defer server.Close()
+
+	smockerInstance := smockerclient.Instance{Url: server.URL}
+	_, err := smockerInstance.VerifySession("Z9gF5kwSR")
+
+	var verificationErr *smockerclient.VerificationError
+	assert.ErrorAs(t, err, &verificationErr)
+	assert.Len(t, verificationErr.UnexpectedCalls, 2)
+
+	expectedMissingHeader := []smockerclient.FieldDifference{
+		{Field: "header Idempotency-Key", Expected: "abc", Actual: "<missing>"},
+	}
+	assert.Equal(t, expectedMissingHeader, verificationErr.UnexpectedCalls[0].ClosestMock.Differences)
+
+	expectedWrongPath := []smockerclient.FieldDifference{
+		{Field: "path ShouldMatch", Expected: "^/orders/[0-9]+$", Actual: "/orders/abc"},
+	}
+	assert.Equal(t, expectedWrongPath, verificationErr.UnexpectedCalls[1].ClosestMock.Differences)
+}
+
+func getVerifyBodyWithUnusedMocksAndUnmatchedCall() string {
+	return `{
+    "mocks": {
+        "verified": true,
+        "all_used": false,
+        "message": "Some mocks don't match expectations",
+        "unused": [
+            {
+                "request": {
+                    "path": {"matcher": "ShouldEqual", "value": "/orders"},
+                    "method": {"matcher": "ShouldEqual", "value": "POST"},
+                    "headers": {
+                        "Idempotency-Key": [{"matcher": "ShouldEqual", "value": "abc"}]
+                    },
+                    "body": {"matcher": "ShouldEqualJSON", "value": "{\"id\": 1234}"}
+                },
+                "response": {"status": 201},
+                "context": {"times": 1},
+                "state": {"id": "orders1", "times_count": 0, "creation_date": "2023-04-26T14:41:43Z"}
+            },
+            {
+                "request": {
+                    "path": {"matcher": "ShouldEqual", "value": "/health"},
+                    "method": {"matcher": "ShouldEqual", "value": "GET"}
+                },
+                "response": {"status": 200},
+                "context": {},
+                "state": {"id": "health1", "times_count": 0, "creation_date": "2023-04-26T14:41:44Z"}
+            }
+        ]
+    },
+    "history": {
+        "verified": false,
+        "message": "There are errors in the history",
+        "failures": [
+            {
+                "context": {},
+                "request": {
+                    "path": "/orders",
+                    "method": "POST",
+                    "body_string": "{\"id\": 5678}",
+                    "body": {"id": 5678},
+                    "query_params": {"dry_run": ["true"]},
+                    "headers": {"Content-Type": ["application/json"]},
+                    "date": "2023-04-26T14:42:53Z"
+                },
+                "response": {
+                    "status": 666,
+                    "body": {"message": "No mock found matching the request"},
+                    "date": "2023-04-26T14:42:53Z"
+                }
+            }
+        ]
+    }
+}`
+}
+
+func getVerifyBodyWhenExtraCallExceedsMock() string {
+	return `{
+    "mocks": {
+        "verified": true,
+        "all_used": true,
+        "message": "All mocks match expectations"
+    },
+    "history": {
+        "verified": false,
+        "message": "There are errors in the history",
+        "failures": [
+            {
+                "context": {},
+                "request": {
+                    "path": "/test",
+                    "method": "GET",
+                    "body": "",
+                    "date": "2023-04-26T14:42:53Z"
+                },
+                "response": {
+                    "status": 666,
+                    "body": {
+                        "message": "Matching mock found but was exceeded",
+                        "nearest": [
+                            {
+                                "request": {"method": "GET", "path": "/test"},
+                                "response": {"status": 200},
+                                "context": {"times": 1},
+                                "state": {"id": "test1", "times_count": 2, "creation_date": "2023-04-26T14:41:43Z"}
+                            }
+                        ]
+                    },
+                    "date": "2023-04-26T14:42:53Z"
+                }
+            }
+        ]
+    }
+}`
+}
+
+func getVerifyBodyWithPathMatcherMock() string {
+	return `{
+    "mocks": {
+        "verified": true,
+        "all_used": false,
+        "message": "Some mocks don't match expectations",
+        "unused": [
+            {
+                "request": {
+                    "path": {"matcher": "ShouldMatch", "value": "^/orders/[0-9]+$"},
+                    "method": {"matcher": "ShouldMatch", "value": "POST|PUT"},
+                    "headers": {
+                        "Idempotency-Key": [{"matcher": "ShouldEqual", "value": "abc"}]
+                    }
+                },
+                "response": {"status": 201},
+                "context": {},
+                "state": {"id": "orders1", "times_count": 0, "creation_date": "2023-04-26T14:41:43Z"}
+            }
+        ]
+    },
+    "history": {
+        "verified": false,
+        "message": "There are errors in the history",
+        "failures": [
+            {
+                "context": {},
+                "request": {
+                    "path": "/orders/42",
+                    "method": "PUT",
+                    "body": "",
+                    "date": "2023-04-26T14:42:53Z"
+                },
+                "response": {
+                    "status": 666,
+                    "body": {"message": "No mock found matching the request"},
+                    "date": "2023-04-26T14:42:53Z"
+                }
+            },
+            {
+                "context": {},
+                "request": {
+                    "path": "/orders/abc",
+                    "method": "POST",
+                    "body": "",
+                    "headers": {"Idempotency-Key": ["abc"]},
+                    "date": "2023-04-26T14:42:54Z"
+                },
+                "response": {
+                    "status": 666,
+                    "body": {"message": "No mock found matching the request"},
+                    "date": "2023-04-26T14:42:54Z"
+                }
+            }
+        ]
+    }
+}`
+}
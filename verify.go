@@ -0,0 +1,76 @@
+package smockerclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// VerificationResult The outcome of verifying a session on the Smocker server.
+type VerificationResult struct {
+	Mocks   MocksVerification   `json:"mocks"`
+	History HistoryVerification `json:"history"`
+}
+
+// MocksVerification Describes whether the mocks in the session were called as expected.
+type MocksVerification struct {
+	Verified bool   `json:"verified"`
+	AllUsed  bool   `json:"all_used"`
+	Message  string `json:"message"`
+	// Failures The mocks that were called more times than they allow.
+	Failures []Mock `json:"failures,omitempty"`
+	// Unused The mocks that were never called.
+	Unused []Mock `json:"unused,omitempty"`
+}
+
+// HistoryVerification Describes whether any calls were made that did not match a mock.
+type HistoryVerification struct {
+	Verified bool   `json:"verified"`
+	Message  string `json:"message"`
+	// Failures The calls that did not match a mock.
+	Failures []HistoryEntry `json:"failures,omitempty"`
+}
+
+// Passed Reports whether all the mocks in the session have been used and no unexpected calls have been made.
+func (r VerificationResult) Passed() bool {
+	return r.Mocks.AllUsed && r.History.Verified
+}
+
+// VerifySession Checks all the mocks in the session with the given id have been called and that no other calls have
+// been made. An empty session id verifies the latest session. The result is returned even when the verification
+// fails, so the unused mocks and unexpected calls can be inspected.
+func (i Instance) VerifySession(sessionID string) (VerificationResult, error) {
+	return i.VerifySessionContext(context.Background(), sessionID)
+}
+
+// VerifySessionContext Is VerifySession with a context to cancel the request and set its deadline.
+func (i Instance) VerifySessionContext(ctx context.Context, sessionID string) (VerificationResult, error) {
+	resp, err := i.sendVerifySessionRequest(ctx, sessionID)
+	if err != nil {
+		return VerificationResult{}, fmt.Errorf("smockerclient unable to verify session %s. %w", sessionID, err)
+	}
+	defer resp.Body.Close()
+
+	err = handleNon200Response(resp)
+	if err != nil {
+		return VerificationResult{}, fmt.Errorf("smockerclient unable to verify session %s. %w", sessionID, err)
+	}
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return VerificationResult{}, fmt.Errorf("smockerclient unable to read response body to verify session %s. %w", sessionID, err)
+	}
+
+	var result VerificationResult
+	err = json.Unmarshal(bodyBytes, &result)
+	if err != nil {
+		return VerificationResult{}, fmt.Errorf("smockerclient unable to read json response to verify session %s. %w", sessionID, err)
+	}
+
+	if !result.Passed() {
+		return result, newVerificationError(sessionID, result)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,128 @@
+package smockerclient_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/churmd/smockerclient"
+)
+
+func TestVerifySession_WhenAllMocksHaveBeenCalled_ReturnsPassedResult(t *testing.T) {
+	serverCallCount := 0
+	sessionID := "Z9gF5kwSR"
+
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				serverCallCount++
+
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Equal(t, "/sessions/verify", r.URL.Path)
+				assert.Equal(t, sessionID, r.URL.Query().Get("session"))
+
+				resp := `{
+				  "mocks": {
+					"verified": true,
+					"all_used": true,
+					"message": "All mocks match expectations"
+				  },
+				  "history": {
+					"verified": true,
+					"message": "History is clean"
+				  }
+				}`
+				_, err := w.Write([]byte(resp))
+				assert.NoError(t, err, "httptest server write failed")
+			},
+		),
+	)
+	defer server.Close()
+
+	smockerInstance := smockerclient.Instance{Url: server.URL}
+	result, err := smockerInstance.VerifySession(sessionID)
+
+	expected := smockerclient.VerificationResult{
+		Mocks: smockerclient.MocksVerification{
+			Verified: true,
+			AllUsed:  true,
+			Message:  "All mocks match expectations",
+		},
+		History: smockerclient.HistoryVerification{
+			Verified: true,
+			Message:  "History is clean",
+		},
+	}
+	assert.NoError(t, err)
+	assert.Equal(t, expected, result)
+	assert.True(t, result.Passed())
+	assert.Equal(t, 1, serverCallCount)
+}
+
+func TestVerifySession_WhenSomeMocksHaveNotBeenCalled_ReturnsResultAndError(t *testing.T) {
+	server := newVerifyServer(t, getVerifyBodyWhenSomeMocksAreNotCalled())
+	defer server.Close()
+
+	smockerInstance := smockerclient.Instance{Url: server.URL}
+	result, err := smockerInstance.VerifySession("Z9gF5kwSR")
+
+	assert.ErrorContains(t, err, "not all the mocks setup in session Z9gF5kwSR have been used")
+	assert.False(t, result.Passed())
+	assert.Len(t, result.Mocks.Unused, 1)
+	assert.Equal(t, "bqeh8ks4R", result.Mocks.Unused[0].State.ID)
+	assert.Equal(t, "/example", result.Mocks.Unused[0].Definition.Request.Path)
+}
+
+func TestVerifySession_WhenExtraCallsHaveBeenMade_ReturnsResultAndError(t *testing.T) {
+	server := newVerifyServer(t, getVerifyBodyWhenExtraCallsHaveBeenMade())
+	defer server.Close()
+
+	smockerInstance := smockerclient.Instance{Url: server.URL}
+	result, err := smockerInstance.VerifySession("Z9gF5kwSR")
+
+	assert.ErrorContains(t, err, "unexpected calls have been made in session Z9gF5kwSR")
+	assert.False(t, result.Passed())
+	assert.Len(t, result.History.Failures, 1)
+	assert.Equal(t, "/example1", result.History.Failures[0].Request.Path)
+	assert.Equal(t, 666, result.History.Failures[0].Response.Status)
+}
+
+func TestVerifySession_WhenMocksAreExceededAndExtraCallsMade_ReturnsResultAndError(t *testing.T) {
+	server := newVerifyServer(t, getVerifyBodyWhenSomeMocksAreNotCalledAndExtraCallsMade())
+	defer server.Close()
+
+	smockerInstance := smockerclient.Instance{Url: server.URL}
+	result, err := smockerInstance.VerifySession("Z9gF5kwSR")
+
+	assert.ErrorContains(t, err, "not all the mocks setup in session Z9gF5kwSR have been used")
+	assert.Len(t, result.Mocks.Failures, 2)
+	assert.Len(t, result.Mocks.Unused, 1)
+	assert.Len(t, result.History.Failures, 1)
+}
+
+func TestVerifySession_WhenServerDoesNotReturn200_ReturnsError(t *testing.T) {
+	server, serverCallCount := newBadResponseServer(t)
+	defer server.Close()
+
+	smockerInstance := smockerclient.Instance{Url: server.URL}
+	_, err := smockerInstance.VerifySession("Z9gF5kwSR")
+
+	assert.Equal(t, 1, *serverCallCount)
+	assert.EqualError(t, err, "smockerclient unable to verify session Z9gF5kwSR. received status:400 and message:400 Bad Request")
+}
+
+func newVerifyServer(t *testing.T, body string) *httptest.Server {
+	return httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Equal(t, "/sessions/verify", r.URL.Path)
+
+				_, err := w.Write([]byte(body))
+				assert.NoError(t, err, "httptest server write failed")
+			},
+		),
+	)
+}